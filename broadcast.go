@@ -0,0 +1,267 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+// PostBroadcastArgs represents arguments for posting a message to many channels
+type PostBroadcastArgs struct {
+	Message  string   `json:"message" jsonschema:"required,description=Message to post; {{team}}, {{team_name}}, {{channel}} and {{channel_name}} are replaced per channel"`
+	Channels []string `json:"channels" jsonschema:"description=Channels to post to (in team:channel format or channel IDs)"`
+	Team     string   `json:"team" jsonschema:"description=Post to the channels of this team matching pattern (name or ID)"`
+	Pattern  string   `json:"pattern" jsonschema:"description=Channel name pattern, required with team (e.g. project-*, or * for every channel)"`
+	AsUserID string   `json:"asUserId" jsonschema:"description=User ID or username to post as (impersonation)"`
+	DelayMs  int      `json:"delayMs" jsonschema:"description=Delay between posts in milliseconds to limit the rate (default 250)"`
+	DryRun   bool     `json:"dryRun" jsonschema:"description=Only list the channels and rendered messages"`
+}
+
+// PostBroadcastDeleteArgs represents arguments for deleting a broadcast
+type PostBroadcastDeleteArgs struct {
+	BatchID   string `json:"batchId" jsonschema:"required,description=Batch ID returned by post_broadcast"`
+	Permanent bool   `json:"permanent" jsonschema:"description=Permanently delete the posts and their contents"`
+}
+
+// BroadcastPost records a post created by a broadcast
+type BroadcastPost struct {
+	Channel string `json:"channel"`
+	PostID  string `json:"postId,omitempty"`
+	Message string `json:"message,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Broadcast records a message posted to many channels
+type Broadcast struct {
+	BatchID string          `json:"batchId"`
+	SentAt  string          `json:"sentAt"`
+	DryRun  bool            `json:"dryRun,omitempty"`
+	Posts   []BroadcastPost `json:"posts"`
+	Warning string          `json:"warning,omitempty"`
+}
+
+// broadcastTarget is a channel selected for a broadcast
+type broadcastTarget struct {
+	ref     string
+	team    *Team
+	channel *Channel
+}
+
+// broadcastTargets resolves the channels a broadcast posts to
+func broadcastTargets(args PostBroadcastArgs) ([]broadcastTarget, error) {
+	var targets []broadcastTarget
+	teams := map[string]*Team{}
+
+	for _, ref := range args.Channels {
+		channel, err := getChannel(ref)
+		if err != nil {
+			return nil, err
+		}
+
+		team, ok := teams[channel.TeamID]
+		if !ok {
+			if team, err = getTeam(channel.TeamID); err != nil {
+				return nil, err
+			}
+			teams[channel.TeamID] = team
+		}
+		targets = append(targets, broadcastTarget{ref: ref, team: team, channel: channel})
+	}
+
+	if args.Team != "" {
+		if args.Pattern == "" {
+			return nil, fmt.Errorf("pattern is required with team; use * to post to every channel of the team")
+		}
+
+		team, err := getTeam(args.Team)
+		if err != nil {
+			return nil, err
+		}
+
+		channels, err := listChannels(team.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, ch := range channels {
+			if ch.DeleteAt != 0 {
+				continue
+			}
+			matched, err := path.Match(args.Pattern, ch.Name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", args.Pattern, err)
+			}
+			if matched {
+				targets = append(targets, broadcastTarget{ref: team.Name + ":" + ch.Name, team: team, channel: &channels[i]})
+			}
+		}
+	}
+
+	return targets, nil
+}
+
+// updateBroadcasts applies update to the stored broadcasts under the data file
+// lock, so that server processes sharing the data directory do not overwrite
+// each other's changes
+func updateBroadcasts(update func(broadcasts map[string]Broadcast) error) error {
+	unlock, err := lockDataFile("broadcasts.json")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	broadcasts := map[string]Broadcast{}
+	if err := loadDataFile("broadcasts.json", &broadcasts); err != nil {
+		return err
+	}
+	if err := update(broadcasts); err != nil {
+		return err
+	}
+	return saveDataFile("broadcasts.json", broadcasts)
+}
+
+// recordBroadcast stores a broadcast so it can later be deleted by batch ID
+func recordBroadcast(broadcast Broadcast) error {
+	return updateBroadcasts(func(broadcasts map[string]Broadcast) error {
+		broadcasts[broadcast.BatchID] = broadcast
+		return nil
+	})
+}
+
+// RegisterBroadcastTools registers all broadcast related tools
+func RegisterBroadcastTools(server *mcp_golang.Server) error {
+	// Register post broadcast tool
+	err := server.RegisterTool("post_broadcast", "Post the same message to many channels, optionally templated per channel, and return a batch ID to delete it later", func(args PostBroadcastArgs) (*mcp_golang.ToolResponse, error) {
+		targets, err := broadcastTargets(args)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		if len(targets) == 0 {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: no channels selected; provide channels, or team and pattern")), nil
+		}
+
+		userID, err := resolveImpersonatedUser(args.AsUserID)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		batchID, err := newID()
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		delay := time.Duration(args.DelayMs) * time.Millisecond
+		if args.DelayMs <= 0 {
+			delay = 250 * time.Millisecond
+		}
+
+		broadcast := Broadcast{BatchID: batchID, SentAt: time.Now().UTC().Format(time.RFC3339), DryRun: args.DryRun, Posts: []BroadcastPost{}}
+		send := func(api *apiClient) error {
+			for i, target := range targets {
+				message := strings.NewReplacer(
+					"{{team}}", target.team.DisplayName,
+					"{{team_name}}", target.team.Name,
+					"{{channel}}", target.channel.DisplayName,
+					"{{channel_name}}", target.channel.Name,
+				).Replace(args.Message)
+
+				result := BroadcastPost{Channel: target.ref, Message: message}
+				if args.DryRun {
+					broadcast.Posts = append(broadcast.Posts, result)
+					continue
+				}
+
+				if i > 0 {
+					time.Sleep(delay)
+				}
+
+				post, err := createRichPostWithAPI(api, PostCreateArgs{
+					Channel: target.channel.ID,
+					Message: message,
+					Props:   map[string]interface{}{"broadcast_batch_id": batchID},
+				})
+				if err != nil {
+					result.Error = err.Error()
+				} else {
+					result.PostID = post.ID
+				}
+				broadcast.Posts = append(broadcast.Posts, result)
+			}
+			return nil
+		}
+
+		// A single impersonation session is shared by all posts of the broadcast
+		if args.DryRun {
+			err = send(nil)
+		} else {
+			err = withUserAPI(userID, send)
+		}
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		if !args.DryRun {
+			if err := recordBroadcast(broadcast); err != nil {
+				broadcast.Warning = fmt.Sprintf("broadcast sent but not recorded, it cannot be deleted by batch ID: %v", err)
+			}
+		}
+		return newJSONToolResponse(broadcast), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register post_broadcast tool: %v", err)
+	}
+
+	// Register post broadcast delete tool
+	err = server.RegisterTool("post_broadcast_delete", "Delete all posts of a broadcast by its batch ID", func(args PostBroadcastDeleteArgs) (*mcp_golang.ToolResponse, error) {
+		// Only read the record under the lock, deleting the posts may take long
+		var broadcast Broadcast
+		ok := false
+		err := updateBroadcasts(func(broadcasts map[string]Broadcast) error {
+			broadcast, ok = broadcasts[args.BatchID]
+			return nil
+		})
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		if !ok {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: broadcast %s not found", args.BatchID))), nil
+		}
+
+		cmdArgs := []string{"post", "delete", "--confirm"}
+
+		if args.Permanent {
+			cmdArgs = append(cmdArgs, "--permanent")
+		}
+
+		count := 0
+		for _, p := range broadcast.Posts {
+			if p.PostID != "" {
+				cmdArgs = append(cmdArgs, p.PostID)
+				count++
+			}
+		}
+
+		if count > 0 {
+			if _, err := executeMMCTL(cmdArgs...); err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+		}
+
+		err = updateBroadcasts(func(broadcasts map[string]Broadcast) error {
+			delete(broadcasts, args.BatchID)
+			return nil
+		})
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Deleted %d posts, but the broadcast record could not be removed: %v", count, err))), nil
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Deleted %d posts of broadcast %s", count, args.BatchID))), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register post_broadcast_delete tool: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+// PostBulkDeleteArgs represents arguments for deleting posts matching criteria
+type PostBulkDeleteArgs struct {
+	Author       string   `json:"author" jsonschema:"description=Only delete posts by this user (username, email, or ID)"`
+	Channels     []string `json:"channels" jsonschema:"description=Only delete posts in these channels (in team:channel format or channel IDs); without channels an author and since are required and all channels they are a member of are scanned"`
+	Since        string   `json:"since" jsonschema:"description=Only delete posts created at or after this time (RFC 3339 or YYYY-MM-DD)"`
+	Until        string   `json:"until" jsonschema:"description=Only delete posts created at or before this time (RFC 3339 or YYYY-MM-DD)"`
+	Pattern      string   `json:"pattern" jsonschema:"description=Only delete posts whose message matches this regular expression"`
+	Permanent    bool     `json:"permanent" jsonschema:"description=Permanently delete the posts and their attached files"`
+	ConfirmToken string   `json:"confirmToken" jsonschema:"description=Token returned by a previous preview; deletes exactly the previewed posts. Without it only a preview is returned"`
+}
+
+// BulkDeleteMatch is a post selected for bulk deletion
+type BulkDeleteMatch struct {
+	PostID    string `json:"postId"`
+	Channel   string `json:"channel"`
+	Author    string `json:"author"`
+	CreatedAt string `json:"createdAt"`
+	Snippet   string `json:"snippet"`
+}
+
+// BulkDeletePreview lists the posts a bulk deletion would remove
+type BulkDeletePreview struct {
+	Total        int               `json:"total"`
+	Permanent    bool              `json:"permanent"`
+	ConfirmToken string            `json:"confirmToken,omitempty"`
+	ExpiresAt    string            `json:"expiresAt,omitempty"`
+	Matches      []BulkDeleteMatch `json:"matches"`
+	Message      string            `json:"message"`
+}
+
+// bulkDeletePending is a previewed deletion waiting for confirmation
+type bulkDeletePending struct {
+	postIDs   []string
+	permanent bool
+	expiresAt time.Time
+}
+
+// bulkDeletePreviewTTL is how long a preview's confirmation token is valid
+const bulkDeletePreviewTTL = 15 * time.Minute
+
+var (
+	// bulkDeleteMutex guards bulkDeletes
+	bulkDeleteMutex sync.Mutex
+	// bulkDeletes holds previewed deletions by confirmation token
+	bulkDeletes = map[string]bulkDeletePending{}
+)
+
+// authorChannelPosts returns the posts created between since and until in the
+// channels a user is a member of, scanning channel history rather than
+// searching, as local mode searches have no user and miss channels
+func authorChannelPosts(userID string, since, until int64) ([]Post, error) {
+	var channels []Channel
+	if err := executeLocalAPI("GET", "/users/"+userID+"/channels?include_deleted=true", nil, &channels); err != nil {
+		return nil, err
+	}
+
+	var posts []Post
+	for _, ch := range channels {
+		channelPosts, err := fetchChannelPosts(ch.ID, since, until)
+		if err != nil {
+			return nil, err
+		}
+		posts = append(posts, channelPosts...)
+	}
+	return posts, nil
+}
+
+// validateBulkDeleteArgs checks the criteria bound the posts to scan
+func validateBulkDeleteArgs(args PostBulkDeleteArgs) error {
+	if len(args.Channels) > 0 {
+		return nil
+	}
+	if args.Author == "" {
+		return fmt.Errorf("an author or at least one channel is required")
+	}
+	// Without it the whole history of every channel of the author is fetched
+	if args.Since == "" {
+		return fmt.Errorf("since is required when no channels are given")
+	}
+	return nil
+}
+
+// findBulkDeletePosts returns the posts matching the bulk deletion criteria
+func findBulkDeletePosts(args PostBulkDeleteArgs) ([]Post, error) {
+	if err := validateBulkDeleteArgs(args); err != nil {
+		return nil, err
+	}
+
+	since, err := parseMillis(args.Since)
+	if err != nil {
+		return nil, err
+	}
+	until, err := parseMillis(args.Until)
+	if err != nil {
+		return nil, err
+	}
+
+	var pattern *regexp.Regexp
+	if args.Pattern != "" {
+		if pattern, err = regexp.Compile(args.Pattern); err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+	}
+
+	var author *User
+	if args.Author != "" {
+		if author, err = getUser(args.Author); err != nil {
+			return nil, err
+		}
+	}
+
+	var candidates []Post
+	if len(args.Channels) > 0 {
+		for _, ref := range args.Channels {
+			channel, err := getChannel(ref)
+			if err != nil {
+				return nil, err
+			}
+			posts, err := fetchChannelPosts(channel.ID, since, until)
+			if err != nil {
+				return nil, err
+			}
+			candidates = append(candidates, posts...)
+		}
+	} else {
+		if candidates, err = authorChannelPosts(author.ID, since, until); err != nil {
+			return nil, err
+		}
+	}
+
+	matches := []Post{}
+	for _, p := range candidates {
+		if p.DeleteAt != 0 {
+			continue
+		}
+		if author != nil && p.UserID != author.ID {
+			continue
+		}
+		if (since > 0 && p.CreateAt < since) || (until > 0 && p.CreateAt > until) {
+			continue
+		}
+		if pattern != nil && !pattern.MatchString(p.Message) {
+			continue
+		}
+		matches = append(matches, p)
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].CreateAt < matches[j].CreateAt })
+	return matches, nil
+}
+
+// bulkDeleteMatches describes posts for a preview, resolving channels and authors
+func bulkDeleteMatches(posts []Post) ([]BulkDeleteMatch, error) {
+	userIDs := []string{}
+	seen := map[string]bool{}
+	for _, p := range posts {
+		if !seen[p.UserID] {
+			seen[p.UserID] = true
+			userIDs = append(userIDs, p.UserID)
+		}
+	}
+	users, err := getUsersByIDs(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	channels := map[string]*Channel{}
+	matches := []BulkDeleteMatch{}
+	for _, p := range posts {
+		channel, ok := channels[p.ChannelID]
+		if !ok {
+			if channel, err = getChannel(p.ChannelID); err != nil {
+				return nil, err
+			}
+			channels[p.ChannelID] = channel
+		}
+
+		author := p.UserID
+		if u, ok := users[p.UserID]; ok {
+			author = u.Username
+		}
+		matches = append(matches, BulkDeleteMatch{
+			PostID:    p.ID,
+			Channel:   channel.Name,
+			Author:    author,
+			CreatedAt: formatMillis(p.CreateAt),
+			Snippet:   searchSnippet(p.Message, nil),
+		})
+	}
+	return matches, nil
+}
+
+// RegisterBulkDeleteTools registers all bulk post deletion related tools
+func RegisterBulkDeleteTools(server *mcp_golang.Server) error {
+	// Register post bulk delete tool
+	err := server.RegisterTool("post_bulk_delete", "Delete posts matching author, channel, time range or content criteria. Always previews first and returns a confirmation token that must be passed back to delete", func(args PostBulkDeleteArgs) (*mcp_golang.ToolResponse, error) {
+		if args.ConfirmToken != "" {
+			bulkDeleteMutex.Lock()
+			pending, ok := bulkDeletes[args.ConfirmToken]
+			delete(bulkDeletes, args.ConfirmToken)
+			bulkDeleteMutex.Unlock()
+
+			if !ok || time.Now().After(pending.expiresAt) {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: confirmation token is unknown or expired; run a new preview")), nil
+			}
+
+			// Delete in batches to keep the mmctl command line short
+			deleted := 0
+			for start := 0; start < len(pending.postIDs); start += 100 {
+				end := start + 100
+				if end > len(pending.postIDs) {
+					end = len(pending.postIDs)
+				}
+
+				cmdArgs := []string{"post", "delete", "--confirm"}
+				if pending.permanent {
+					cmdArgs = append(cmdArgs, "--permanent")
+				}
+				cmdArgs = append(cmdArgs, pending.postIDs[start:end]...)
+
+				if _, err := executeMMCTL(cmdArgs...); err != nil {
+					return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error after deleting %d of %d posts: %v", deleted, len(pending.postIDs), err))), nil
+				}
+				deleted = end
+			}
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Deleted %d posts", deleted))), nil
+		}
+
+		posts, err := findBulkDeletePosts(args)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		matches, err := bulkDeleteMatches(posts)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		preview := BulkDeletePreview{Total: len(matches), Permanent: args.Permanent, Matches: matches}
+		if len(matches) == 0 {
+			preview.Message = "No posts match the criteria"
+			return newJSONToolResponse(preview), nil
+		}
+
+		token, err := newID()
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		postIDs := make([]string, 0, len(posts))
+		for _, p := range posts {
+			postIDs = append(postIDs, p.ID)
+		}
+		expiresAt := time.Now().Add(bulkDeletePreviewTTL)
+
+		bulkDeleteMutex.Lock()
+		for t, pending := range bulkDeletes {
+			if time.Now().After(pending.expiresAt) {
+				delete(bulkDeletes, t)
+			}
+		}
+		bulkDeletes[token] = bulkDeletePending{postIDs: postIDs, permanent: args.Permanent, expiresAt: expiresAt}
+		bulkDeleteMutex.Unlock()
+
+		preview.ConfirmToken = token
+		preview.ExpiresAt = expiresAt.UTC().Format(time.RFC3339)
+		action := "deleted"
+		if args.Permanent {
+			action = "permanently deleted with their files"
+		}
+		preview.Message = fmt.Sprintf("Nothing deleted yet. Call again with confirmToken %q to have these %d posts %s", token, len(matches), action)
+		if len(preview.Matches) > 100 {
+			preview.Matches = preview.Matches[:100]
+			preview.Message += "; only the first 100 are listed"
+		}
+		return newJSONToolResponse(preview), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register post_bulk_delete tool: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package main
+
+import "testing"
+
+func TestValidateBulkDeleteArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    PostBulkDeleteArgs
+		wantErr bool
+	}{
+		{"nothing", PostBulkDeleteArgs{}, true},
+		{"pattern only", PostBulkDeleteArgs{Pattern: "spam"}, true},
+		{"author without since", PostBulkDeleteArgs{Author: "spammer"}, true},
+		{"author with until only", PostBulkDeleteArgs{Author: "spammer", Until: "2026-10-01"}, true},
+		{"author with since", PostBulkDeleteArgs{Author: "spammer", Since: "2026-10-01"}, false},
+		{"channels", PostBulkDeleteArgs{Channels: []string{"team:town-square"}}, false},
+		{"channels and author", PostBulkDeleteArgs{Author: "spammer", Channels: []string{"team:town-square"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBulkDeleteArgs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateBulkDeleteArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,430 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+// CategoryListArgs represents arguments for listing a user's sidebar categories
+type CategoryListArgs struct {
+	User string `json:"user" jsonschema:"required,description=User (username, email, or ID)"`
+	Team string `json:"team" jsonschema:"required,description=Team name or ID"`
+}
+
+// CategoryCreateArgs represents arguments for creating a sidebar category
+type CategoryCreateArgs struct {
+	User        string   `json:"user" jsonschema:"required,description=User (username, email, or ID)"`
+	Team        string   `json:"team" jsonschema:"required,description=Team name or ID"`
+	DisplayName string   `json:"displayName" jsonschema:"required,description=Category name"`
+	Channels    []string `json:"channels" jsonschema:"description=Channels to place in the category (channel names in the team or IDs)"`
+}
+
+// CategoryRenameArgs represents arguments for renaming a sidebar category
+type CategoryRenameArgs struct {
+	User        string `json:"user" jsonschema:"required,description=User (username, email, or ID)"`
+	Team        string `json:"team" jsonschema:"required,description=Team name or ID"`
+	Category    string `json:"category" jsonschema:"required,description=Category name or ID"`
+	DisplayName string `json:"displayName" jsonschema:"required,description=New category name"`
+}
+
+// CategoryReorderArgs represents arguments for reordering sidebar categories
+type CategoryReorderArgs struct {
+	User  string   `json:"user" jsonschema:"required,description=User (username, email, or ID)"`
+	Team  string   `json:"team" jsonschema:"required,description=Team name or ID"`
+	Order []string `json:"order" jsonschema:"required,description=Category names or IDs in the desired order; unlisted categories keep their relative order after them"`
+}
+
+// CategoryAddChannelsArgs represents arguments for moving channels into a sidebar category
+type CategoryAddChannelsArgs struct {
+	User     string   `json:"user" jsonschema:"required,description=User (username, email, or ID)"`
+	Team     string   `json:"team" jsonschema:"required,description=Team name or ID"`
+	Category string   `json:"category" jsonschema:"required,description=Category name or ID"`
+	Channels []string `json:"channels" jsonschema:"required,description=Channels to move into the category (channel names in the team or IDs)"`
+}
+
+// CategoryLayout describes one category of a standard sidebar layout
+type CategoryLayout struct {
+	DisplayName string   `json:"displayName" jsonschema:"required,description=Category name"`
+	Channels    []string `json:"channels" jsonschema:"description=Channels in the category (channel names in the team or IDs)"`
+}
+
+// CategoryPushLayoutArgs represents arguments for pushing a sidebar layout to team members
+type CategoryPushLayoutArgs struct {
+	Team   string           `json:"team" jsonschema:"required,description=Team name or ID"`
+	Layout []CategoryLayout `json:"layout" jsonschema:"required,description=Categories to create, in sidebar order"`
+	Users  []string         `json:"users" jsonschema:"description=Only push the layout to these members (all active members if omitted)"`
+	DryRun bool             `json:"dryRun" jsonschema:"description=Only report the categories that would be created or updated for each user"`
+}
+
+// CategoryPushResult reports the outcome of pushing a layout to one user
+type CategoryPushResult struct {
+	User    string   `json:"user"`
+	Created []string `json:"created"`
+	Updated []string `json:"updated"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// categoriesPath returns the API path of a user's sidebar categories in a team
+func categoriesPath(userID, teamID string) string {
+	return "/users/" + userID + "/teams/" + teamID + "/channels/categories"
+}
+
+// getCategories fetches a user's sidebar categories in a team
+func getCategories(userID, teamID string) (*SidebarCategories, error) {
+	var categories SidebarCategories
+	if err := executeLocalAPI("GET", categoriesPath(userID, teamID), nil, &categories); err != nil {
+		return nil, err
+	}
+	return &categories, nil
+}
+
+// findCategory looks up a category by ID or case-insensitive display name
+func findCategory(categories *SidebarCategories, ref string) *SidebarCategory {
+	for i, c := range categories.Categories {
+		if c.ID == ref || strings.EqualFold(c.DisplayName, ref) {
+			return &categories.Categories[i]
+		}
+	}
+	return nil
+}
+
+// findCustomCategory looks up a custom category by case-insensitive display
+// name, ignoring system categories such as Favorites or Channels
+func findCustomCategory(categories *SidebarCategories, name string) *SidebarCategory {
+	for i, c := range categories.Categories {
+		if c.Type == "custom" && strings.EqualFold(c.DisplayName, name) {
+			return &categories.Categories[i]
+		}
+	}
+	return nil
+}
+
+// resolveChannelIDs converts channel names of a team, team:channel references
+// or channel IDs into channel IDs
+func resolveChannelIDs(team *Team, refs []string) ([]string, error) {
+	ids := []string{}
+	for _, ref := range refs {
+		if !strings.Contains(ref, ":") && len(ref) != 26 {
+			ref = team.Name + ":" + ref
+		}
+		channel, err := getChannel(ref)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, channel.ID)
+	}
+	return ids, nil
+}
+
+// categoryContext resolves the user and team a category tool operates on
+func categoryContext(userRef, teamRef string) (*User, *Team, error) {
+	user, err := getUser(userRef)
+	if err != nil {
+		return nil, nil, err
+	}
+	team, err := getTeam(teamRef)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, team, nil
+}
+
+// pushCategoryLayout creates or updates the layout categories for a user,
+// only placing channels the user is a member of. Channels are added to
+// existing categories, keeping the ones the user placed there. With dryRun
+// nothing is changed and the result lists what would be created or updated
+func pushCategoryLayout(user User, team *Team, layout []CategoryLayout, channelIDs [][]string, dryRun bool) CategoryPushResult {
+	result := CategoryPushResult{User: user.Username, Created: []string{}, Updated: []string{}}
+
+	var memberships []Channel
+	if err := executeLocalAPI("GET", "/users/"+user.ID+"/teams/"+team.ID+"/channels", nil, &memberships); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	member := map[string]bool{}
+	for _, ch := range memberships {
+		member[ch.ID] = true
+	}
+
+	categories, err := getCategories(user.ID, team.ID)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	order := []string{}
+	for i, l := range layout {
+		ids := []string{}
+		for _, id := range channelIDs[i] {
+			if member[id] {
+				ids = append(ids, id)
+			}
+		}
+
+		if existing := findCustomCategory(categories, l.DisplayName); existing != nil {
+			present := map[string]bool{}
+			for _, id := range existing.ChannelIDs {
+				present[id] = true
+			}
+			added := false
+			for _, id := range ids {
+				if !present[id] {
+					existing.ChannelIDs = append(existing.ChannelIDs, id)
+					added = true
+				}
+			}
+			order = append(order, existing.ID)
+			if !added {
+				continue
+			}
+
+			if !dryRun {
+				if err := executeLocalAPI("PUT", categoriesPath(user.ID, team.ID)+"/"+existing.ID, existing, nil); err != nil {
+					result.Error = fmt.Sprintf("%s: %v", l.DisplayName, err)
+					return result
+				}
+			}
+			result.Updated = append(result.Updated, l.DisplayName)
+			continue
+		}
+
+		if dryRun {
+			result.Created = append(result.Created, l.DisplayName)
+			continue
+		}
+
+		var created SidebarCategory
+		category := SidebarCategory{UserID: user.ID, TeamID: team.ID, DisplayName: l.DisplayName, ChannelIDs: ids}
+		if err := executeLocalAPI("POST", categoriesPath(user.ID, team.ID), category, &created); err != nil {
+			result.Error = fmt.Sprintf("%s: %v", l.DisplayName, err)
+			return result
+		}
+		order = append(order, created.ID)
+		result.Created = append(result.Created, l.DisplayName)
+	}
+
+	if dryRun {
+		return result
+	}
+	if err := reorderCategories(user.ID, team.ID, order); err != nil {
+		result.Error = fmt.Sprintf("order: %v", err)
+	}
+	return result
+}
+
+// reorderCategories moves the given categories to the top of the sidebar,
+// keeping the remaining categories in their current order
+func reorderCategories(userID, teamID string, first []string) error {
+	var current []string
+	if err := executeLocalAPI("GET", categoriesPath(userID, teamID)+"/order", nil, &current); err != nil {
+		return err
+	}
+
+	placed := map[string]bool{}
+	for _, id := range first {
+		placed[id] = true
+	}
+
+	order := append([]string{}, first...)
+	for _, id := range current {
+		if !placed[id] {
+			order = append(order, id)
+		}
+	}
+	return executeLocalAPI("PUT", categoriesPath(userID, teamID)+"/order", order, nil)
+}
+
+// RegisterCategoryTools registers all sidebar category related tools
+func RegisterCategoryTools(server *mcp_golang.Server) error {
+	// Register category list tool
+	err := server.RegisterTool("category_list", "List a user's sidebar categories in a team", func(args CategoryListArgs) (*mcp_golang.ToolResponse, error) {
+		user, team, err := categoryContext(args.User, args.Team)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		categories, err := getCategories(user.ID, team.ID)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		return newJSONToolResponse(categories), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register category_list tool: %v", err)
+	}
+
+	// Register category create tool
+	err = server.RegisterTool("category_create", "Create a sidebar category for a user", func(args CategoryCreateArgs) (*mcp_golang.ToolResponse, error) {
+		user, team, err := categoryContext(args.User, args.Team)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		channelIDs, err := resolveChannelIDs(team, args.Channels)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		var created SidebarCategory
+		category := SidebarCategory{UserID: user.ID, TeamID: team.ID, DisplayName: args.DisplayName, ChannelIDs: channelIDs}
+		if err := executeLocalAPI("POST", categoriesPath(user.ID, team.ID), category, &created); err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		return newJSONToolResponse(created), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register category_create tool: %v", err)
+	}
+
+	// Register category rename tool
+	err = server.RegisterTool("category_rename", "Rename a user's sidebar category", func(args CategoryRenameArgs) (*mcp_golang.ToolResponse, error) {
+		user, team, err := categoryContext(args.User, args.Team)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		categories, err := getCategories(user.ID, team.ID)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		category := findCategory(categories, args.Category)
+		if category == nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: category %s not found", args.Category))), nil
+		}
+		if category.Type != "custom" {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: only custom categories can be renamed")), nil
+		}
+
+		category.DisplayName = args.DisplayName
+		if err := executeLocalAPI("PUT", categoriesPath(user.ID, team.ID)+"/"+category.ID, category, nil); err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Category renamed successfully")), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register category_rename tool: %v", err)
+	}
+
+	// Register category reorder tool
+	err = server.RegisterTool("category_reorder", "Reorder a user's sidebar categories", func(args CategoryReorderArgs) (*mcp_golang.ToolResponse, error) {
+		user, team, err := categoryContext(args.User, args.Team)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		categories, err := getCategories(user.ID, team.ID)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		first := []string{}
+		for _, ref := range args.Order {
+			category := findCategory(categories, ref)
+			if category == nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: category %s not found", ref))), nil
+			}
+			first = append(first, category.ID)
+		}
+
+		if err := reorderCategories(user.ID, team.ID, first); err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Categories reordered successfully")), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register category_reorder tool: %v", err)
+	}
+
+	// Register category add channels tool
+	err = server.RegisterTool("category_add_channels", "Move channels into a user's sidebar category", func(args CategoryAddChannelsArgs) (*mcp_golang.ToolResponse, error) {
+		user, team, err := categoryContext(args.User, args.Team)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		categories, err := getCategories(user.ID, team.ID)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		category := findCategory(categories, args.Category)
+		if category == nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: category %s not found", args.Category))), nil
+		}
+
+		channelIDs, err := resolveChannelIDs(team, args.Channels)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		existing := map[string]bool{}
+		for _, id := range category.ChannelIDs {
+			existing[id] = true
+		}
+		for _, id := range channelIDs {
+			if !existing[id] {
+				category.ChannelIDs = append(category.ChannelIDs, id)
+			}
+		}
+
+		if err := executeLocalAPI("PUT", categoriesPath(user.ID, team.ID)+"/"+category.ID, category, nil); err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Channels moved to category successfully")), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register category_add_channels tool: %v", err)
+	}
+
+	// Register category push layout tool
+	err = server.RegisterTool("category_push_layout", "Push a standard sidebar category layout to the members of a team", func(args CategoryPushLayoutArgs) (*mcp_golang.ToolResponse, error) {
+		team, err := getTeam(args.Team)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		channelIDs := [][]string{}
+		for _, l := range args.Layout {
+			ids, err := resolveChannelIDs(team, l.Channels)
+			if err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+			channelIDs = append(channelIDs, ids)
+		}
+
+		var users []User
+		if len(args.Users) > 0 {
+			for _, ref := range args.Users {
+				user, err := getUser(ref)
+				if err != nil {
+					return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+				}
+				users = append(users, *user)
+			}
+		} else {
+			members, err := listUsers(team.ID)
+			if err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+			for _, m := range members {
+				if m.DeleteAt == 0 && !m.IsBot {
+					users = append(users, m)
+				}
+			}
+		}
+
+		results := []CategoryPushResult{}
+		for _, user := range users {
+			results = append(results, pushCategoryLayout(user, team, args.Layout, channelIDs, args.DryRun))
+		}
+		return newJSONToolResponse(results), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register category_push_layout tool: %v", err)
+	}
+
+	return nil
+}
@@ -2,10 +2,86 @@ package main
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	mcp_golang "github.com/metoro-io/mcp-golang"
 )
 
+// ChannelMember represents the membership of a user in a channel
+type ChannelMember struct {
+	ChannelID     string `json:"channel_id"`
+	UserID        string `json:"user_id"`
+	Roles         string `json:"roles"`
+	SchemeAdmin   bool   `json:"scheme_admin"`
+	SchemeUser    bool   `json:"scheme_user"`
+	SchemeGuest   bool   `json:"scheme_guest"`
+	ExplicitRoles string `json:"explicit_roles"`
+	LastViewedAt  int64  `json:"last_viewed_at"`
+	MsgCount      int64  `json:"msg_count"`
+}
+
+// Channel represents the fields of a Mattermost channel used by the tools
+type Channel struct {
+	ID               string `json:"id"`
+	TeamID           string `json:"team_id"`
+	Type             string `json:"type"`
+	Name             string `json:"name"`
+	DisplayName      string `json:"display_name"`
+	Header           string `json:"header"`
+	Purpose          string `json:"purpose"`
+	CreatorID        string `json:"creator_id"`
+	GroupConstrained *bool  `json:"group_constrained"`
+	CreateAt         int64  `json:"create_at"`
+	LastPostAt       int64  `json:"last_post_at"`
+	TotalMsgCount    int64  `json:"total_msg_count"`
+	DeleteAt         int64  `json:"delete_at"`
+}
+
+// SidebarCategory represents a user's sidebar category in a team
+type SidebarCategory struct {
+	ID          string   `json:"id,omitempty"`
+	UserID      string   `json:"user_id"`
+	TeamID      string   `json:"team_id"`
+	Type        string   `json:"type,omitempty"`
+	DisplayName string   `json:"display_name"`
+	Sorting     string   `json:"sorting,omitempty"`
+	Muted       bool     `json:"muted"`
+	Collapsed   bool     `json:"collapsed"`
+	ChannelIDs  []string `json:"channel_ids"`
+}
+
+// SidebarCategories represents the sidebar categories of a user in a team
+type SidebarCategories struct {
+	Categories []SidebarCategory `json:"categories"`
+	Order      []string          `json:"order"`
+}
+
+// listChannels fetches all public, private and archived channels of a team
+func listChannels(team string) ([]Channel, error) {
+	var channels []Channel
+	if err := executeMMCTLJSON(&channels, "channel", "list", team); err != nil {
+		return nil, err
+	}
+	return channels, nil
+}
+
+// getChannel looks up a single channel given as team:channel or channel ID,
+// including archived channels
+func getChannel(ref string) (*Channel, error) {
+	var channel Channel
+	if team, name, ok := strings.Cut(ref, ":"); ok {
+		if err := executeLocalAPI("GET", "/teams/name/"+team+"/channels/name/"+name+"?include_deleted=true", nil, &channel); err != nil {
+			return nil, fmt.Errorf("channel %s not found: %w", ref, err)
+		}
+		return &channel, nil
+	}
+	if err := executeLocalAPI("GET", "/channels/"+ref, nil, &channel); err != nil {
+		return nil, fmt.Errorf("channel %s not found: %w", ref, err)
+	}
+	return &channel, nil
+}
+
 // ChannelListArgs represents arguments for channel list command
 type ChannelListArgs struct {
 	Team string `json:"team" jsonschema:"description=Team name or ID to filter channels by"`
@@ -37,6 +113,282 @@ type ChannelUnarchiveArgs struct {
 	Channel string `json:"channel" jsonschema:"required,description=Channel name or ID to unarchive (in team:channel format for named channels)"`
 }
 
+// ChannelRenameArgs represents arguments for channel rename command
+type ChannelRenameArgs struct {
+	Channel     string `json:"channel" jsonschema:"required,description=Channel name or ID to rename (in team:channel format for named channels)"`
+	Name        string `json:"name" jsonschema:"description=New channel name (lowercase, no spaces)"`
+	DisplayName string `json:"displayName" jsonschema:"description=New channel display name"`
+}
+
+// ChannelModifyArgs represents arguments for modifying a channel
+type ChannelModifyArgs struct {
+	Channel string  `json:"channel" jsonschema:"required,description=Channel name or ID to modify (in team:channel format for named channels)"`
+	Header  *string `json:"header" jsonschema:"description=New channel header"`
+	Purpose *string `json:"purpose" jsonschema:"description=New channel purpose"`
+	Privacy string  `json:"privacy" jsonschema:"enum=public,enum=private,description=Convert the channel to public or private"`
+}
+
+// ChannelMoveArgs represents arguments for channel move command
+type ChannelMoveArgs struct {
+	Team     string   `json:"team" jsonschema:"required,description=Destination team name or ID"`
+	Channels []string `json:"channels" jsonschema:"required,description=Channels to move (in team:channel format or channel IDs)"`
+	Force    bool     `json:"force" jsonschema:"description=Remove members that are not in the destination team"`
+}
+
+// ChannelDeleteArgs represents arguments for channel delete command
+type ChannelDeleteArgs struct {
+	Channel string `json:"channel" jsonschema:"required,description=Channel to permanently delete (in team:channel format or channel ID)"`
+	Confirm string `json:"confirm" jsonschema:"description=Must repeat the channel argument to confirm deletion; without it only the impact is reported"`
+}
+
+// ChannelDeleteImpact reports what a permanent channel deletion affects
+type ChannelDeleteImpact struct {
+	Channel string `json:"channel"`
+	Posts   int64  `json:"posts"`
+	Members int64  `json:"members"`
+	Deleted bool   `json:"deleted"`
+	Message string `json:"message"`
+}
+
+// ChannelMembersListArgs represents arguments for listing channel members
+type ChannelMembersListArgs struct {
+	Channel string `json:"channel" jsonschema:"required,description=Channel name or ID (in team:channel format for named channels)"`
+	Page    int    `json:"page" jsonschema:"description=Page number"`
+	PerPage int    `json:"perPage" jsonschema:"description=Number of members per page (default 60)"`
+}
+
+// ChannelMembersRemoveArgs represents arguments for channel users remove command
+type ChannelMembersRemoveArgs struct {
+	Channel  string   `json:"channel" jsonschema:"required,description=Channel name or ID (in team:channel format for named channels)"`
+	Users    []string `json:"users" jsonschema:"description=Users to remove (usernames, emails, or IDs)"`
+	AllUsers bool     `json:"allUsers" jsonschema:"description=Remove all users from the channel"`
+	DryRun   bool     `json:"dryRun" jsonschema:"description=Only report the members that would be removed"`
+}
+
+// ChannelMemberInfo summarizes a channel member and their roles
+type ChannelMemberInfo struct {
+	UserID      string `json:"userId"`
+	Username    string `json:"username"`
+	Email       string `json:"email"`
+	Roles       string `json:"roles"`
+	Admin       bool   `json:"admin"`
+	Guest       bool   `json:"guest"`
+	GroupSynced *bool  `json:"groupSynced,omitempty"`
+}
+
+// ChannelRemovePreview reports the members a removal would affect
+type ChannelRemovePreview struct {
+	Channel      string              `json:"channel"`
+	TotalMembers int                 `json:"totalMembers"`
+	WouldRemove  []ChannelMemberInfo `json:"wouldRemove"`
+}
+
+// listChannelMembers fetches a page of channel members with their user details.
+// A negative page fetches every member. The group sync status is left unset
+// when the linked groups cannot be read, for example without an LDAP license.
+func listChannelMembers(channel *Channel, page, perPage int) ([]ChannelMemberInfo, error) {
+	var members []ChannelMember
+	if page >= 0 {
+		if err := executeLocalAPI("GET", fmt.Sprintf("/channels/%s/members?page=%d&per_page=%d", channel.ID, page, perPage), nil, &members); err != nil {
+			return nil, err
+		}
+	} else {
+		for p := 0; ; p++ {
+			var batch []ChannelMember
+			if err := executeLocalAPI("GET", fmt.Sprintf("/channels/%s/members?page=%d&per_page=200", channel.ID, p), nil, &batch); err != nil {
+				return nil, err
+			}
+			members = append(members, batch...)
+			if len(batch) < 200 {
+				break
+			}
+		}
+	}
+
+	ids := []string{}
+	for _, m := range members {
+		ids = append(ids, m.UserID)
+	}
+	users, err := getUsersByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// Direct and group messages cannot be linked to groups
+	direct := channel.Type == "D" || channel.Type == "G"
+	synced := map[string]bool{}
+	known := true
+	if !direct {
+		if synced, err = groupMemberIDs("/channels/" + channel.ID); err != nil {
+			known = false
+		}
+	}
+	constrained := channel.GroupConstrained != nil && *channel.GroupConstrained
+
+	infos := []ChannelMemberInfo{}
+	for _, m := range members {
+		info := ChannelMemberInfo{
+			UserID:   m.UserID,
+			Username: users[m.UserID].Username,
+			Email:    users[m.UserID].Email,
+			Roles:    m.Roles,
+			Admin:    m.SchemeAdmin,
+			Guest:    m.SchemeGuest,
+		}
+		if known {
+			groupSynced := constrained || synced[m.UserID]
+			info.GroupSynced = &groupSynced
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// groupMemberIDs returns the IDs of the users belonging to the groups linked
+// to a team or channel. syncablePath is the API path of the team or channel.
+func groupMemberIDs(syncablePath string) (map[string]bool, error) {
+	groups, err := linkedGroups(syncablePath)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := map[string]bool{}
+	for _, g := range groups {
+		for page := 0; ; page++ {
+			var resp struct {
+				Members []User `json:"members"`
+			}
+			if err := executeLocalAPI("GET", fmt.Sprintf("/groups/%s/members?page=%d&per_page=200", g.ID, page), nil, &resp); err != nil {
+				return nil, err
+			}
+			for _, u := range resp.Members {
+				ids[u.ID] = true
+			}
+			if len(resp.Members) < 200 {
+				break
+			}
+		}
+	}
+	return ids, nil
+}
+
+// ChannelStaleReportArgs represents arguments for the stale channel report
+type ChannelStaleReportArgs struct {
+	Team           string `json:"team" jsonschema:"description=Team name or ID to scan (all teams if omitted)"`
+	Days           int    `json:"days" jsonschema:"required,description=Report channels without posts in this many days"`
+	IncludePrivate bool   `json:"includePrivate" jsonschema:"description=Also scan private channels"`
+}
+
+// ChannelArchiveBulkArgs represents arguments for archiving many channels
+type ChannelArchiveBulkArgs struct {
+	Channels []string `json:"channels" jsonschema:"required,description=Channels to archive (in team:channel format or channel IDs)"`
+	Notice   string   `json:"notice" jsonschema:"description=Message posted in each channel before archiving it"`
+	AsUserID string   `json:"asUserId" jsonschema:"description=User ID or username to post the notice as (impersonation)"`
+}
+
+// StaleChannel reports a channel without recent posts
+type StaleChannel struct {
+	Channel      string `json:"channel"`
+	DisplayName  string `json:"displayName"`
+	Private      bool   `json:"private"`
+	LastPost     string `json:"lastPost"`
+	InactiveDays int    `json:"inactiveDays"`
+	Members      int64  `json:"members"`
+	Error        string `json:"error,omitempty"`
+}
+
+// ChannelArchiveResult reports the outcome of archiving one channel
+type ChannelArchiveResult struct {
+	Channel    string `json:"channel"`
+	NoticeSent bool   `json:"noticeSent"`
+	Archived   bool   `json:"archived"`
+	Error      string `json:"error,omitempty"`
+}
+
+// findStaleChannels scans the active channels of a team for ones without
+// user posts since the cutoff. Channels that cannot be checked are reported
+// with their error instead of aborting the scan
+func findStaleChannels(team *Team, cutoff time.Time, includePrivate bool) ([]StaleChannel, error) {
+	channels, err := listChannels(team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	stale := []StaleChannel{}
+	for _, ch := range channels {
+		if ch.DeleteAt != 0 || (ch.Type == "P" && !includePrivate) || (ch.Type != "O" && ch.Type != "P") {
+			continue
+		}
+
+		ref := team.Name + ":" + ch.Name
+		last := ch.CreateAt
+		post, err := latestUserPost(ch.ID)
+		if err != nil {
+			stale = append(stale, StaleChannel{Channel: ref, DisplayName: ch.DisplayName, Private: ch.Type == "P", Error: err.Error()})
+			continue
+		}
+		if post != nil {
+			last = post.CreateAt
+		}
+		if last >= cutoff.UnixMilli() {
+			continue
+		}
+
+		var stats struct {
+			MemberCount int64 `json:"member_count"`
+		}
+		lastPost := ""
+		if post != nil {
+			lastPost = formatMillis(post.CreateAt)
+		}
+		channel := StaleChannel{
+			Channel:      ref,
+			DisplayName:  ch.DisplayName,
+			Private:      ch.Type == "P",
+			LastPost:     lastPost,
+			InactiveDays: int(time.Since(time.UnixMilli(last)).Hours() / 24),
+		}
+		if err := executeLocalAPI("GET", "/channels/"+ch.ID+"/stats", nil, &stats); err != nil {
+			channel.Error = fmt.Sprintf("member count: %v", err)
+		}
+		channel.Members = stats.MemberCount
+		stale = append(stale, channel)
+	}
+	return stale, nil
+}
+
+// ChannelFindArgs represents arguments for searching channels across teams
+type ChannelFindArgs struct {
+	Term             string   `json:"term" jsonschema:"description=Substring of the channel name or display name"`
+	Purpose          string   `json:"purpose" jsonschema:"description=Substring of the channel purpose"`
+	Teams            []string `json:"teams" jsonschema:"description=Only search these teams (names or IDs); all teams if omitted"`
+	Public           bool     `json:"public" jsonschema:"description=Include public channels"`
+	Private          bool     `json:"private" jsonschema:"description=Include private channels"`
+	Archived         bool     `json:"archived" jsonschema:"description=Only return archived channels"`
+	GroupConstrained *bool    `json:"groupConstrained" jsonschema:"description=Only return channels with (true) or without (false) group constraints"`
+	MinMembers       int      `json:"minMembers" jsonschema:"description=Minimum number of members"`
+	MaxMembers       int      `json:"maxMembers" jsonschema:"description=Maximum number of members"`
+}
+
+// channelWithTeam is a channel returned by the search API with its team name
+type channelWithTeam struct {
+	Channel
+	TeamName string `json:"team_name"`
+}
+
+// ChannelFindResult describes a channel matched by a search
+type ChannelFindResult struct {
+	Channel          string `json:"channel"`
+	ID               string `json:"id"`
+	DisplayName      string `json:"displayName"`
+	Team             string `json:"team"`
+	Private          bool   `json:"private"`
+	Archived         bool   `json:"archived"`
+	GroupConstrained bool   `json:"groupConstrained"`
+	Purpose          string `json:"purpose,omitempty"`
+	Members          int64  `json:"members"`
+}
+
 // RegisterChannelTools registers all channel related tools
 func RegisterChannelTools(server *mcp_golang.Server) error {
 	// Register channel list tool
@@ -146,5 +498,350 @@ func RegisterChannelTools(server *mcp_golang.Server) error {
 		return fmt.Errorf("failed to register channel_unarchive tool: %v", err)
 	}
 
+	// Register channel rename tool
+	err = server.RegisterTool("channel_rename", "Rename a channel", func(args ChannelRenameArgs) (*mcp_golang.ToolResponse, error) {
+		if args.Name == "" && args.DisplayName == "" {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: name or displayName must be provided")), nil
+		}
+
+		cmdArgs := []string{"channel", "rename", args.Channel}
+
+		if args.Name != "" {
+			cmdArgs = append(cmdArgs, "--name", args.Name)
+		}
+
+		if args.DisplayName != "" {
+			cmdArgs = append(cmdArgs, "--display-name", args.DisplayName)
+		}
+
+		output, err := executeMMCTL(cmdArgs...)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		if output == "" {
+			output = "Channel renamed successfully"
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(output)), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register channel_rename tool: %v", err)
+	}
+
+	// Register channel modify tool
+	err = server.RegisterTool("channel_modify", "Modify a channel's header, purpose or privacy", func(args ChannelModifyArgs) (*mcp_golang.ToolResponse, error) {
+		if args.Privacy != "" && args.Privacy != "public" && args.Privacy != "private" {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: privacy must be public or private")), nil
+		}
+
+		channel, err := getChannel(args.Channel)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		patch := map[string]string{}
+		if args.Header != nil {
+			patch["header"] = *args.Header
+		}
+		if args.Purpose != nil {
+			patch["purpose"] = *args.Purpose
+		}
+		if len(patch) > 0 {
+			if err := executeLocalAPI("PUT", "/channels/"+channel.ID+"/patch", patch, nil); err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+		}
+
+		if args.Privacy != "" {
+			if _, err := executeMMCTL("channel", "modify", channel.ID, "--"+args.Privacy); err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+		}
+
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Channel modified successfully")), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register channel_modify tool: %v", err)
+	}
+
+	// Register channel move tool
+	err = server.RegisterTool("channel_move", "Move channels to another team", func(args ChannelMoveArgs) (*mcp_golang.ToolResponse, error) {
+		cmdArgs := []string{"channel", "move", args.Team}
+		cmdArgs = append(cmdArgs, args.Channels...)
+
+		if args.Force {
+			cmdArgs = append(cmdArgs, "--force")
+		}
+
+		output, err := executeMMCTL(cmdArgs...)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		if output == "" {
+			output = "Channels moved successfully"
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(output)), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register channel_move tool: %v", err)
+	}
+
+	// Register channel delete tool
+	err = server.RegisterTool("channel_delete", "Permanently delete a channel. Reports the affected posts and members unless confirm repeats the channel argument", func(args ChannelDeleteArgs) (*mcp_golang.ToolResponse, error) {
+		channel, err := getChannel(args.Channel)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		var stats struct {
+			MemberCount int64 `json:"member_count"`
+		}
+		if err := executeLocalAPI("GET", "/channels/"+channel.ID+"/stats", nil, &stats); err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		impact := ChannelDeleteImpact{Channel: args.Channel, Posts: channel.TotalMsgCount, Members: stats.MemberCount}
+		if args.Confirm != args.Channel {
+			impact.Message = fmt.Sprintf("Channel not deleted. Call again with confirm set to %q to permanently delete it", args.Channel)
+			return newJSONToolResponse(impact), nil
+		}
+
+		if _, err := executeMMCTL("channel", "delete", "--confirm", channel.ID); err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		impact.Deleted = true
+		impact.Message = "Channel permanently deleted"
+		return newJSONToolResponse(impact), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register channel_delete tool: %v", err)
+	}
+
+	// Register channel members list tool
+	err = server.RegisterTool("channel_members_list", "List the members of a channel with their roles and group sync status; groupSynced is left out when the linked groups cannot be read, for example without an LDAP license", func(args ChannelMembersListArgs) (*mcp_golang.ToolResponse, error) {
+		channel, err := getChannel(args.Channel)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		perPage := args.PerPage
+		if perPage <= 0 {
+			perPage = 60
+		}
+
+		members, err := listChannelMembers(channel, args.Page, perPage)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		return newJSONToolResponse(members), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register channel_members_list tool: %v", err)
+	}
+
+	// Register channel members remove tool
+	err = server.RegisterTool("channel_members_remove", "Remove users from a channel", func(args ChannelMembersRemoveArgs) (*mcp_golang.ToolResponse, error) {
+		if len(args.Users) == 0 && !args.AllUsers {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: users or allUsers must be provided")), nil
+		}
+
+		if args.DryRun {
+			channel, err := getChannel(args.Channel)
+			if err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+
+			members, err := listChannelMembers(channel, -1, 0)
+			if err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+
+			requested := map[string]bool{}
+			for _, u := range args.Users {
+				requested[strings.ToLower(u)] = true
+			}
+
+			removed := []ChannelMemberInfo{}
+			for _, m := range members {
+				if args.AllUsers || requested[m.UserID] || requested[strings.ToLower(m.Username)] || requested[strings.ToLower(m.Email)] {
+					removed = append(removed, m)
+				}
+			}
+			return newJSONToolResponse(ChannelRemovePreview{Channel: args.Channel, TotalMembers: len(members), WouldRemove: removed}), nil
+		}
+
+		cmdArgs := []string{"channel", "users", "remove", args.Channel}
+
+		if args.AllUsers {
+			cmdArgs = append(cmdArgs, "--all-users")
+		} else {
+			cmdArgs = append(cmdArgs, args.Users...)
+		}
+
+		output, err := executeMMCTL(cmdArgs...)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		if output == "" {
+			output = "Users removed from channel successfully"
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(output)), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register channel_members_remove tool: %v", err)
+	}
+
+	// Register stale channel report tool
+	err = server.RegisterTool("channel_stale_report", "Report channels without user posts in N days, ignoring system messages such as joins and leaves, with their member counts. Channels that could not be checked are listed with an error", func(args ChannelStaleReportArgs) (*mcp_golang.ToolResponse, error) {
+		if args.Days <= 0 {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: days must be greater than zero")), nil
+		}
+
+		var teams []Team
+		if args.Team != "" {
+			team, err := getTeam(args.Team)
+			if err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+			teams = []Team{*team}
+		} else {
+			all, err := listTeams()
+			if err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+			for _, t := range all {
+				if t.DeleteAt == 0 {
+					teams = append(teams, t)
+				}
+			}
+		}
+
+		cutoff := time.Now().AddDate(0, 0, -args.Days)
+		stale := []StaleChannel{}
+		for i := range teams {
+			channels, err := findStaleChannels(&teams[i], cutoff, args.IncludePrivate)
+			if err != nil {
+				stale = append(stale, StaleChannel{Channel: teams[i].Name, Error: fmt.Sprintf("channels not listed: %v", err)})
+				continue
+			}
+			stale = append(stale, channels...)
+		}
+		return newJSONToolResponse(stale), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register channel_stale_report tool: %v", err)
+	}
+
+	// Register bulk channel archive tool
+	err = server.RegisterTool("channel_archive_bulk", "Archive many channels, optionally posting a notice in each one first", func(args ChannelArchiveBulkArgs) (*mcp_golang.ToolResponse, error) {
+		results := []ChannelArchiveResult{}
+		for _, channel := range args.Channels {
+			result := ChannelArchiveResult{Channel: channel}
+
+			if args.Notice != "" {
+				if _, err := createPost(PostCreateArgs{Channel: channel, Message: args.Notice, AsUserID: args.AsUserID}); err != nil {
+					result.Error = fmt.Sprintf("notice: %v", err)
+					results = append(results, result)
+					continue
+				}
+				result.NoticeSent = true
+			}
+
+			if _, err := executeMMCTL("channel", "archive", channel); err != nil {
+				result.Error = err.Error()
+				results = append(results, result)
+				continue
+			}
+			result.Archived = true
+			results = append(results, result)
+		}
+		return newJSONToolResponse(results), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register channel_archive_bulk tool: %v", err)
+	}
+
+	// Register channel find tool
+	err = server.RegisterTool("channel_find", "Search channels across all teams with filters for privacy, archived state, purpose, member count and group constraints", func(args ChannelFindArgs) (*mcp_golang.ToolResponse, error) {
+		search := map[string]interface{}{
+			"term":     args.Term,
+			"per_page": 200,
+		}
+
+		// Without an explicit privacy filter both kinds are searched
+		if args.Public != args.Private {
+			search["public"] = args.Public
+			search["private"] = args.Private
+		}
+
+		if args.Archived {
+			search["deleted"] = true
+		}
+
+		if args.GroupConstrained != nil {
+			search["group_constrained"] = *args.GroupConstrained
+			search["exclude_group_constrained"] = !*args.GroupConstrained
+		}
+
+		if len(args.Teams) > 0 {
+			teamIDs := []string{}
+			for _, t := range args.Teams {
+				team, err := getTeam(t)
+				if err != nil {
+					return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+				}
+				teamIDs = append(teamIDs, team.ID)
+			}
+			search["team_ids"] = teamIDs
+		}
+
+		var channels []channelWithTeam
+		for page := 0; ; page++ {
+			search["page"] = page
+			var resp struct {
+				Channels []channelWithTeam `json:"channels"`
+			}
+			if err := executeLocalAPI("POST", "/channels/search", search, &resp); err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+			channels = append(channels, resp.Channels...)
+			if len(resp.Channels) < 200 {
+				break
+			}
+		}
+
+		results := []ChannelFindResult{}
+		for _, ch := range channels {
+			if args.Purpose != "" && !strings.Contains(strings.ToLower(ch.Purpose), strings.ToLower(args.Purpose)) {
+				continue
+			}
+
+			var stats struct {
+				MemberCount int64 `json:"member_count"`
+			}
+			if err := executeLocalAPI("GET", "/channels/"+ch.ID+"/stats", nil, &stats); err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+			if stats.MemberCount < int64(args.MinMembers) || (args.MaxMembers > 0 && stats.MemberCount > int64(args.MaxMembers)) {
+				continue
+			}
+
+			results = append(results, ChannelFindResult{
+				Channel:          ch.TeamName + ":" + ch.Name,
+				ID:               ch.ID,
+				DisplayName:      ch.DisplayName,
+				Team:             ch.TeamName,
+				Private:          ch.Type == "P",
+				Archived:         ch.DeleteAt != 0,
+				GroupConstrained: ch.GroupConstrained != nil && *ch.GroupConstrained,
+				Purpose:          ch.Purpose,
+				Members:          stats.MemberCount,
+			})
+		}
+		return newJSONToolResponse(results), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register channel_find tool: %v", err)
+	}
+
 	return nil
 }
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+// DirectChannelFindArgs represents arguments for finding direct and group message channels
+type DirectChannelFindArgs struct {
+	Users []string `json:"users" jsonschema:"required,description=Users taking part in the conversation (username, email, or ID); two users match their direct message channel"`
+	Exact bool     `json:"exact" jsonschema:"description=Only return group messages whose members are exactly these users"`
+}
+
+// DirectChannelExportArgs represents arguments for exporting a direct or group message channel
+type DirectChannelExportArgs struct {
+	ChannelID  string `json:"channelId" jsonschema:"required,description=ID of the direct or group message channel"`
+	Format     string `json:"format" jsonschema:"enum=markdown,enum=json,description=Export format (default markdown)"`
+	OutputPath string `json:"outputPath" jsonschema:"description=Write the export to this local file instead of returning it"`
+}
+
+// DirectChannelDeleteArgs represents arguments for deleting a direct or group message channel
+type DirectChannelDeleteArgs struct {
+	ChannelID string `json:"channelId" jsonschema:"required,description=ID of the direct or group message channel"`
+	Confirm   string `json:"confirm" jsonschema:"description=Must repeat the channel ID to confirm deletion; without it only the impact is reported"`
+}
+
+// DirectChannelInfo describes a direct or group message channel
+type DirectChannelInfo struct {
+	ID       string   `json:"id"`
+	Type     string   `json:"type"`
+	Members  []string `json:"members"`
+	Posts    int64    `json:"posts"`
+	LastPost string   `json:"lastPost,omitempty"`
+	Deleted  bool     `json:"deleted,omitempty"`
+	Message  string   `json:"message,omitempty"`
+}
+
+// getDirectChannel fetches a direct or group message channel and its member usernames
+func getDirectChannel(channelID string) (*DirectChannelInfo, error) {
+	channel, err := getChannel(channelID)
+	if err != nil {
+		return nil, err
+	}
+	if channel.Type != "D" && channel.Type != "G" {
+		return nil, fmt.Errorf("channel %s is not a direct or group message channel", channelID)
+	}
+
+	members, err := listChannelMembers(channel, -1, 0)
+	if err != nil {
+		return nil, err
+	}
+	return newDirectChannelInfo(channel, members), nil
+}
+
+// newDirectChannelInfo describes a direct or group message channel with its members
+func newDirectChannelInfo(channel *Channel, members []ChannelMemberInfo) *DirectChannelInfo {
+	info := &DirectChannelInfo{ID: channel.ID, Type: "direct", Members: []string{}, Posts: channel.TotalMsgCount, LastPost: formatMillis(channel.LastPostAt)}
+	if channel.Type == "G" {
+		info.Type = "group"
+	}
+	for _, m := range members {
+		info.Members = append(info.Members, m.Username)
+	}
+	sort.Strings(info.Members)
+	return info
+}
+
+// RegisterDirectChannelTools registers all direct and group message channel related tools
+func RegisterDirectChannelTools(server *mcp_golang.Server) error {
+	// Register direct channel find tool
+	err := server.RegisterTool("direct_channel_find", "Find direct and group message channels between users", func(args DirectChannelFindArgs) (*mcp_golang.ToolResponse, error) {
+		if len(args.Users) < 2 {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: at least two users are required")), nil
+		}
+
+		userIDs := map[string]bool{}
+		var first *User
+		for _, ref := range args.Users {
+			user, err := getUser(ref)
+			if err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+			if first == nil {
+				first = user
+			}
+			userIDs[user.ID] = true
+		}
+
+		var channels []Channel
+		if err := executeLocalAPI("GET", "/users/"+first.ID+"/channels?include_deleted=true", nil, &channels); err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		results := []DirectChannelInfo{}
+		for _, ch := range channels {
+			if ch.Type != "D" && ch.Type != "G" {
+				continue
+			}
+			// Direct messages only ever have two members
+			if ch.Type == "D" && len(userIDs) != 2 {
+				continue
+			}
+
+			members, err := listChannelMembers(&ch, -1, 0)
+			if err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+
+			found := 0
+			for _, m := range members {
+				if userIDs[m.UserID] {
+					found++
+				}
+			}
+			if found < len(userIDs) || (args.Exact && len(members) != len(userIDs)) {
+				continue
+			}
+
+			results = append(results, *newDirectChannelInfo(&ch, members))
+		}
+		return newJSONToolResponse(results), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register direct_channel_find tool: %v", err)
+	}
+
+	// Register direct channel export tool
+	err = server.RegisterTool("direct_channel_export", "Export the full history of a direct or group message channel", func(args DirectChannelExportArgs) (*mcp_golang.ToolResponse, error) {
+		info, err := getDirectChannel(args.ChannelID)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		posts, err := fetchChannelPosts(info.ID, 0, 0)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		title := fmt.Sprintf("%s message between %v", info.Type, info.Members)
+		export, err := exportPosts(title, posts, args.Format)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		if args.OutputPath != "" {
+			if err := os.WriteFile(args.OutputPath, []byte(export), 0o600); err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Exported %d posts to %s", len(posts), args.OutputPath))), nil
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(export)), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register direct_channel_export tool: %v", err)
+	}
+
+	// Register direct channel delete tool
+	err = server.RegisterTool("direct_channel_delete", "Permanently delete a direct or group message channel. Reports its members and post count unless confirm repeats the channel ID", func(args DirectChannelDeleteArgs) (*mcp_golang.ToolResponse, error) {
+		info, err := getDirectChannel(args.ChannelID)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		if args.Confirm != info.ID {
+			info.Message = fmt.Sprintf("Channel not deleted. Call again with confirm set to %q to permanently delete it", info.ID)
+			return newJSONToolResponse(info), nil
+		}
+
+		if _, err := executeMMCTL("channel", "delete", "--confirm", info.ID); err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		info.Deleted = true
+		info.Message = "Channel permanently deleted"
+		return newJSONToolResponse(info), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register direct_channel_delete tool: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+// GuestListArgs represents arguments for listing guest accounts
+type GuestListArgs struct {
+	Team     string `json:"team" jsonschema:"description=Only list guests that are members of this team (name or ID)"`
+	Inactive bool   `json:"inactive" jsonschema:"description=Include deactivated guests"`
+}
+
+// GuestPromoteArgs represents arguments for user promote command
+type GuestPromoteArgs struct {
+	Guests []string `json:"guests" jsonschema:"required,description=Guests to promote to regular users (username, email, or ID)"`
+}
+
+// GuestDemoteArgs represents arguments for user demote command
+type GuestDemoteArgs struct {
+	Users []string `json:"users" jsonschema:"required,description=Users to demote to guests (username, email, or ID)"`
+}
+
+// GuestRestrictChannelsArgs represents arguments for restricting a guest to a set of channels
+type GuestRestrictChannelsArgs struct {
+	Guest    string   `json:"guest" jsonschema:"required,description=Guest to restrict (username, email, or ID)"`
+	Channels []string `json:"channels" jsonschema:"required,description=Channels the guest may access (in team:channel format or channel IDs)"`
+	DryRun   bool     `json:"dryRun" jsonschema:"description=Only report the channel changes without applying them"`
+}
+
+// GuestDeactivateInactiveArgs represents arguments for deactivating inactive guests
+type GuestDeactivateInactiveArgs struct {
+	Days   int    `json:"days" jsonschema:"required,description=Deactivate guests with no login, session use or posts in this many days"`
+	Team   string `json:"team" jsonschema:"description=Only consider guests that are members of this team (name or ID)"`
+	DryRun bool   `json:"dryRun" jsonschema:"description=Only list the guests that would be deactivated"`
+}
+
+// GuestInfo summarizes a guest account
+type GuestInfo struct {
+	ID           string `json:"id"`
+	Username     string `json:"username"`
+	Email        string `json:"email"`
+	AuthService  string `json:"authService,omitempty"`
+	Active       bool   `json:"active"`
+	LastActivity string `json:"lastActivity"`
+}
+
+// GuestRestrictResult reports the channel changes for a restricted guest
+type GuestRestrictResult struct {
+	Guest   string   `json:"guest"`
+	DryRun  bool     `json:"dryRun"`
+	Kept    []string `json:"kept"`
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// GuestDeactivateResult reports the guests deactivated for inactivity
+type GuestDeactivateResult struct {
+	Cutoff      string      `json:"cutoff"`
+	DryRun      bool        `json:"dryRun"`
+	Deactivated []GuestInfo `json:"deactivated"`
+	Errors      []string    `json:"errors,omitempty"`
+}
+
+// newGuestInfo builds the summary of a guest account
+func newGuestInfo(u User) GuestInfo {
+	return GuestInfo{
+		ID:           u.ID,
+		Username:     u.Username,
+		Email:        u.Email,
+		AuthService:  u.AuthService,
+		Active:       u.DeleteAt == 0,
+		LastActivity: formatMillis(u.LastActivity()),
+	}
+}
+
+// formatMillis formats a Mattermost millisecond timestamp as RFC 3339
+func formatMillis(ms int64) string {
+	if ms == 0 {
+		return ""
+	}
+	return time.UnixMilli(ms).UTC().Format(time.RFC3339)
+}
+
+// listGuests fetches all guest accounts, optionally restricted to a team
+func listGuests(team string, inactive bool) ([]User, error) {
+	users, err := listUsers(team)
+	if err != nil {
+		return nil, err
+	}
+
+	var guests []User
+	for _, u := range users {
+		if !u.IsGuest() {
+			continue
+		}
+		if u.DeleteAt != 0 && !inactive {
+			continue
+		}
+		guests = append(guests, u)
+	}
+	return guests, nil
+}
+
+// inactiveGuests returns the guests without login, session use or posts since
+// the cutoff. Guests whose activity cannot be checked are reported as errors
+// and never returned
+func inactiveGuests(guests []User, cutoff time.Time) ([]User, []string) {
+	activity := newUserActivityChecker(cutoff.UnixMilli())
+	inactive := []User{}
+	var errs []string
+	for _, g := range guests {
+		last, err := activity.lastActive(g)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: activity not checked, guest skipped: %v", g.Username, err))
+			continue
+		}
+		if last < cutoff.UnixMilli() {
+			inactive = append(inactive, g)
+		}
+	}
+	return inactive, errs
+}
+
+// RegisterGuestTools registers all guest account related tools
+func RegisterGuestTools(server *mcp_golang.Server) error {
+	// Register guest list tool
+	err := server.RegisterTool("guest_list", "List guest accounts", func(args GuestListArgs) (*mcp_golang.ToolResponse, error) {
+		guests, err := listGuests(args.Team, args.Inactive)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		infos := []GuestInfo{}
+		for _, g := range guests {
+			infos = append(infos, newGuestInfo(g))
+		}
+		return newJSONToolResponse(infos), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register guest_list tool: %v", err)
+	}
+
+	// Register guest promote tool
+	err = server.RegisterTool("guest_promote", "Promote guests to regular users", func(args GuestPromoteArgs) (*mcp_golang.ToolResponse, error) {
+		cmdArgs := []string{"user", "promote"}
+		cmdArgs = append(cmdArgs, args.Guests...)
+
+		output, err := executeMMCTL(cmdArgs...)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		if output == "" {
+			output = "Guests promoted to users successfully"
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(output)), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register guest_promote tool: %v", err)
+	}
+
+	// Register guest demote tool
+	err = server.RegisterTool("guest_demote", "Demote users to guests", func(args GuestDemoteArgs) (*mcp_golang.ToolResponse, error) {
+		cmdArgs := []string{"user", "demote"}
+		cmdArgs = append(cmdArgs, args.Users...)
+
+		output, err := executeMMCTL(cmdArgs...)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		if output == "" {
+			output = "Users demoted to guests successfully"
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(output)), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register guest_demote tool: %v", err)
+	}
+
+	// Register guest restrict channels tool
+	err = server.RegisterTool("guest_restrict_channels", "Restrict a guest to a set of channels, adding missing memberships and removing all others", func(args GuestRestrictChannelsArgs) (*mcp_golang.ToolResponse, error) {
+		guest, err := getUser(args.Guest)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		if !guest.IsGuest() {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: user %s is not a guest", guest.Username))), nil
+		}
+
+		var teams []Team
+		if err := executeLocalAPI("GET", "/users/"+guest.ID+"/teams", nil, &teams); err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		allowed := map[string]bool{}
+		for _, c := range args.Channels {
+			allowed[c] = true
+		}
+
+		result := GuestRestrictResult{Guest: guest.Username, DryRun: args.DryRun, Kept: []string{}, Added: []string{}, Removed: []string{}}
+		present := map[string]bool{}
+		for _, team := range teams {
+			var channels []Channel
+			if err := executeLocalAPI("GET", "/users/"+guest.ID+"/teams/"+team.ID+"/channels", nil, &channels); err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+
+			for _, ch := range channels {
+				// Direct and group messages are not team channels
+				if ch.Type == "D" || ch.Type == "G" {
+					continue
+				}
+
+				name := team.Name + ":" + ch.Name
+				if allowed[name] || allowed[ch.ID] {
+					present[name] = true
+					present[ch.ID] = true
+					result.Kept = append(result.Kept, name)
+					continue
+				}
+
+				if !args.DryRun {
+					if _, err := executeMMCTL("channel", "users", "remove", name, guest.ID); err != nil {
+						result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", name, err))
+						continue
+					}
+				}
+				result.Removed = append(result.Removed, name)
+			}
+		}
+
+		for _, c := range args.Channels {
+			if present[c] {
+				continue
+			}
+			if !args.DryRun {
+				if _, err := executeMMCTL("channel", "users", "add", c, guest.ID); err != nil {
+					result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", c, err))
+					continue
+				}
+			}
+			result.Added = append(result.Added, c)
+		}
+
+		return newJSONToolResponse(result), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register guest_restrict_channels tool: %v", err)
+	}
+
+	// Register guest deactivate inactive tool
+	err = server.RegisterTool("guest_deactivate_inactive", "Deactivate guests with no login, session use or posts in the given number of days", func(args GuestDeactivateInactiveArgs) (*mcp_golang.ToolResponse, error) {
+		if args.Days <= 0 {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: days must be greater than zero")), nil
+		}
+
+		guests, err := listGuests(args.Team, false)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		cutoff := time.Now().AddDate(0, 0, -args.Days)
+		result := GuestDeactivateResult{Cutoff: cutoff.UTC().Format(time.RFC3339), DryRun: args.DryRun, Deactivated: []GuestInfo{}}
+		inactive, errs := inactiveGuests(guests, cutoff)
+		result.Errors = append(result.Errors, errs...)
+		for _, g := range inactive {
+			if !args.DryRun {
+				if _, err := executeMMCTL("user", "deactivate", g.ID); err != nil {
+					result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", g.Username, err))
+					continue
+				}
+			}
+			result.Deactivated = append(result.Deactivated, newGuestInfo(g))
+		}
+
+		return newJSONToolResponse(result), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register guest_deactivate_inactive tool: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// serveLocalAPI serves handler as the local mode API for the duration of the test
+func serveLocalAPI(t *testing.T, handler http.Handler) {
+	t.Helper()
+
+	socket := filepath.Join(t.TempDir(), "local.socket")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("listening on %s: %v", socket, err)
+	}
+	server := &http.Server{Handler: handler}
+	go server.Serve(listener)
+	t.Cleanup(func() { server.Close() })
+
+	t.Setenv("MMCTL_LOCAL_SOCKET_PATH", socket)
+}
+
+// respondJSON returns a handler writing v as JSON
+func respondJSON(v interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(v)
+	}
+}
+
+func TestInactiveGuests(t *testing.T) {
+	now := time.Now()
+	cutoff := now.AddDate(0, 0, -30)
+	old := now.AddDate(0, 0, -90).UnixMilli()
+	recent := now.AddDate(0, 0, -1).UnixMilli()
+
+	mux := http.NewServeMux()
+	mux.Handle("/api/v4/users/session/sessions", respondJSON([]Session{{ID: "s1", LastActivityAt: recent}}))
+	mux.Handle("/api/v4/users/poster/sessions", respondJSON([]Session{{ID: "s2", LastActivityAt: old}}))
+	mux.Handle("/api/v4/users/idle/sessions", respondJSON([]Session{}))
+	mux.Handle("/api/v4/users/poster/channels", respondJSON([]Channel{{ID: "town"}}))
+	mux.Handle("/api/v4/users/idle/channels", respondJSON([]Channel{{ID: "town"}}))
+	mux.Handle("/api/v4/channels/town/posts", respondJSON(PostList{
+		Order: []string{"p2", "p1"},
+		Posts: map[string]Post{
+			"p2": {ID: "p2", UserID: "idle", CreateAt: recent, Type: "system_join_channel"},
+			"p1": {ID: "p1", UserID: "poster", CreateAt: recent},
+		},
+	}))
+	mux.Handle("/api/v4/users/broken/sessions", http.NotFoundHandler())
+	serveLocalAPI(t, mux)
+
+	guests := []User{
+		{ID: "login", Username: "login", CreateAt: old, LastLogin: recent},
+		{ID: "session", Username: "session", CreateAt: old, LastLogin: old},
+		{ID: "poster", Username: "poster", CreateAt: old, LastLogin: old},
+		{ID: "idle", Username: "idle", CreateAt: old, LastLogin: old},
+		{ID: "broken", Username: "broken", CreateAt: old, LastLogin: old},
+	}
+
+	inactive, errs := inactiveGuests(guests, cutoff)
+	if len(inactive) != 1 || inactive[0].ID != "idle" {
+		t.Errorf("inactive guests = %v, want only idle", inactive)
+	}
+	if len(errs) != 1 {
+		t.Errorf("errors = %v, want one error for broken", errs)
+	}
+}
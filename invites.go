@@ -0,0 +1,234 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+// TeamInviteArgs represents arguments for inviting people to a team by email
+type TeamInviteArgs struct {
+	Team     string   `json:"team" jsonschema:"required,description=Team name or ID"`
+	Emails   []string `json:"emails" jsonschema:"required,description=Email addresses to invite"`
+	Guest    bool     `json:"guest" jsonschema:"description=Invite as guests restricted to the given channels"`
+	Channels []string `json:"channels" jsonschema:"description=Channels guests get access to (in team:channel format or channel IDs), required for guest invites"`
+	Message  string   `json:"message" jsonschema:"description=Custom message included in guest invitations"`
+}
+
+// TeamInviteListArgs represents arguments for listing invites that may still be pending
+type TeamInviteListArgs struct {
+	Team string `json:"team" jsonschema:"description=Only list invites for this team (name or ID)"`
+}
+
+// TeamInviteRevokeAllArgs represents arguments for revoking pending invites
+type TeamInviteRevokeAllArgs struct {
+	Confirm bool `json:"confirm" jsonschema:"required,description=Must be true, as Mattermost revokes every pending email invite on the server at once"`
+}
+
+// TeamInvite records an email invitation sent through this server
+type TeamInvite struct {
+	Email    string   `json:"email"`
+	Team     string   `json:"team"`
+	TeamID   string   `json:"teamId"`
+	Guest    bool     `json:"guest"`
+	Channels []string `json:"channels,omitempty"`
+	SentAt   string   `json:"sentAt"`
+}
+
+// TeamInviteResult reports the outcome of inviting one email address
+type TeamInviteResult struct {
+	Email string `json:"email"`
+	Sent  bool   `json:"sent"`
+	Error string `json:"error,omitempty"`
+}
+
+// loadInvites reads the invites sent through this server
+func loadInvites() ([]TeamInvite, error) {
+	invites := []TeamInvite{}
+	if err := loadDataFile("invites.json", &invites); err != nil {
+		return nil, err
+	}
+	return invites, nil
+}
+
+// updateInvites applies update to the recorded invites under the data file
+// lock, so that server processes sharing the data directory do not overwrite
+// each other's changes
+func updateInvites(update func([]TeamInvite) []TeamInvite) error {
+	unlock, err := lockDataFile("invites.json")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	invites, err := loadInvites()
+	if err != nil {
+		return err
+	}
+	return saveDataFile("invites.json", update(invites))
+}
+
+// recordInvites appends sent invites to the invites file
+func recordInvites(sent []TeamInvite) error {
+	return updateInvites(func(invites []TeamInvite) []TeamInvite {
+		return append(invites, sent...)
+	})
+}
+
+// RegisterInviteTools registers all team invitation related tools
+func RegisterInviteTools(server *mcp_golang.Server) error {
+	// Register team invite tool
+	err := server.RegisterTool("team_invite", "Invite people to a team by email, as regular members or as guests restricted to channels", func(args TeamInviteArgs) (*mcp_golang.ToolResponse, error) {
+		team, err := getTeam(args.Team)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		results := []TeamInviteResult{}
+		var warnings []string
+		record := func(email string) {
+			invite := TeamInvite{
+				Email:    email,
+				Team:     team.Name,
+				TeamID:   team.ID,
+				Guest:    args.Guest,
+				Channels: args.Channels,
+				SentAt:   time.Now().UTC().Format(time.RFC3339),
+			}
+			if err := recordInvites([]TeamInvite{invite}); err != nil {
+				warnings = append(warnings, fmt.Sprintf("invite to %s sent but not recorded: %v", email, err))
+			}
+		}
+
+		if args.Guest {
+			if len(args.Channels) == 0 {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: guest invites require at least one channel")), nil
+			}
+
+			channelIDs := []string{}
+			for _, ref := range args.Channels {
+				channel, err := getChannel(ref)
+				if err != nil {
+					return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+				}
+				channelIDs = append(channelIDs, channel.ID)
+			}
+
+			body := map[string]interface{}{
+				"emails":   args.Emails,
+				"channels": channelIDs,
+				"message":  args.Message,
+			}
+			// Graceful mode reports failures per email instead of failing the whole batch
+			var outcomes []struct {
+				Email string `json:"email"`
+				Error *struct {
+					Message string `json:"message"`
+				} `json:"error"`
+			}
+			if err := executeLocalAPI("POST", "/teams/"+team.ID+"/invite-guests/email?graceful=true", body, &outcomes); err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+			for _, o := range outcomes {
+				if o.Error != nil {
+					results = append(results, TeamInviteResult{Email: o.Email, Error: o.Error.Message})
+					continue
+				}
+				record(o.Email)
+				results = append(results, TeamInviteResult{Email: o.Email, Sent: true})
+			}
+		} else {
+			for _, email := range args.Emails {
+				if _, err := executeMMCTL("user", "invite", email, team.ID); err != nil {
+					results = append(results, TeamInviteResult{Email: email, Error: err.Error()})
+					continue
+				}
+				record(email)
+				results = append(results, TeamInviteResult{Email: email, Sent: true})
+			}
+		}
+
+		response := struct {
+			Results  []TeamInviteResult `json:"results"`
+			Warnings []string           `json:"warnings,omitempty"`
+		}{results, warnings}
+		return newJSONToolResponse(response), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register team_invite tool: %v", err)
+	}
+
+	// Register team invite list tool
+	err = server.RegisterTool("team_invite_list", "List email invites sent through this server whose recipients have not joined the team yet. Mattermost does not expose pending invites, so invites sent elsewhere are not listed and expired invites may still be", func(args TeamInviteListArgs) (*mcp_golang.ToolResponse, error) {
+		invites, err := loadInvites()
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		// Invites of people that are now team members were accepted
+		members := map[string]map[string]bool{}
+		filtered := []TeamInvite{}
+		for _, invite := range invites {
+			if args.Team != "" && args.Team != invite.Team && args.Team != invite.TeamID {
+				continue
+			}
+
+			emails, ok := members[invite.TeamID]
+			if !ok {
+				users, err := listUsers(invite.TeamID)
+				if err != nil {
+					return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+				}
+				emails = map[string]bool{}
+				for _, u := range users {
+					emails[strings.ToLower(u.Email)] = true
+				}
+				members[invite.TeamID] = emails
+			}
+			if emails[strings.ToLower(invite.Email)] {
+				continue
+			}
+			filtered = append(filtered, invite)
+		}
+		return newJSONToolResponse(filtered), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register team_invite_list tool: %v", err)
+	}
+
+	// Register team invite revoke tool
+	err = server.RegisterTool("team_invite_revoke_all", "Revoke all pending email invites on the server", func(args TeamInviteRevokeAllArgs) (*mcp_golang.ToolResponse, error) {
+		if !args.Confirm {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: confirm must be true to revoke all pending invites")), nil
+		}
+
+		// Invites recorded from the second of the revocation on may have been
+		// sent afterwards by another process, so they are kept
+		revokedAt := time.Now().UTC().Truncate(time.Second)
+		if err := executeLocalAPI("DELETE", "/teams/invites/email", nil, nil); err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		err := updateInvites(func(invites []TeamInvite) []TeamInvite {
+			kept := []TeamInvite{}
+			for _, invite := range invites {
+				if sentAt, err := time.Parse(time.RFC3339, invite.SentAt); err == nil && !sentAt.Before(revokedAt) {
+					kept = append(kept, invite)
+				}
+			}
+			return kept
+		})
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Invites revoked, but the local invite list could not be cleared: %v", err))), nil
+		}
+
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("All pending email invites revoked successfully")), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register team_invite_revoke_all tool: %v", err)
+	}
+
+	return nil
+}
@@ -1,10 +1,23 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"reflect"
 	"strings"
+	"sync"
+	"time"
 
 	mcp_golang "github.com/metoro-io/mcp-golang"
 	"github.com/metoro-io/mcp-golang/transport/stdio"
@@ -35,8 +48,18 @@ type TeamListArgs struct {
 
 // executeMMCTL runs the mmctl command with given arguments
 func executeMMCTL(args ...string) (string, error) {
+	return executeMMCTLAsUser("", args...)
+}
+
+// executeMMCTLAsUser runs the mmctl command impersonating the given user ID,
+// or as the local administrator if userID is empty
+func executeMMCTLAsUser(userID string, args ...string) (string, error) {
 	// Always add --local mode
-	localArgs := append([]string{"--local"}, args...)
+	localArgs := []string{"--local"}
+	if userID != "" {
+		localArgs = append(localArgs, "--local-user-id", userID)
+	}
+	localArgs = append(localArgs, args...)
 	cmd := exec.Command("mmctl", localArgs...)
 	
 	output, err := cmd.CombinedOutput()
@@ -47,6 +70,391 @@ func executeMMCTL(args ...string) (string, error) {
 	return string(output), nil
 }
 
+// executeMMCTLJSON runs the mmctl command with JSON output and decodes it into out
+func executeMMCTLJSON(out interface{}, args ...string) error {
+	localArgs := append([]string{"--local", "--json"}, args...)
+	cmd := exec.Command("mmctl", localArgs...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("error executing mmctl: %w\nOutput: %s%s", err, string(output), stderr.String())
+	}
+
+	data := bytes.TrimSpace(output)
+	if len(data) == 0 {
+		return nil
+	}
+
+	// mmctl prints a bare object instead of a list when there is a single result
+	if data[0] == '{' && reflect.TypeOf(out).Elem().Kind() == reflect.Slice {
+		data = append(append([]byte{'['}, data...), ']')
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("error decoding mmctl output: %w\nOutput: %s", err, string(output))
+	}
+
+	return nil
+}
+
+// localSocketPath returns the path of the Mattermost local mode socket
+func localSocketPath() string {
+	if path := os.Getenv("MMCTL_LOCAL_SOCKET_PATH"); path != "" {
+		return path
+	}
+	return "/var/tmp/mattermost_local.socket"
+}
+
+// executeLocalAPI calls the Mattermost REST API through the local mode socket,
+// for operations that mmctl does not expose as commands
+func executeLocalAPI(method, path string, body, out interface{}) error {
+	return localAPIClient().call(method, path, body, out)
+}
+
+// apiClient calls the Mattermost REST API, either through the local mode
+// socket or over the site URL authenticated as a user
+type apiClient struct {
+	client  *http.Client
+	baseURL string
+	token   string
+}
+
+// localAPIClient returns a client for the local mode socket
+func localAPIClient() *apiClient {
+	return &apiClient{
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, "unix", localSocketPath())
+				},
+			},
+		},
+		baseURL: "http://_",
+	}
+}
+
+// impersonationTokenPrefix starts the description of the personal access
+// tokens generated to act as users
+const impersonationTokenPrefix = "mmctl-mcp impersonation"
+
+const (
+	// impersonationTokenTTL is how long a generated token is reused
+	impersonationTokenTTL = 10 * time.Minute
+	// impersonationTokenGrace is how long calls may keep using a token after
+	// its reuse ended before it is considered stale and revoked
+	impersonationTokenGrace = 30 * time.Minute
+)
+
+// impersonationToken is a personal access token reused to act as a user
+type impersonationToken struct {
+	token      string
+	reuseUntil time.Time
+}
+
+var (
+	// impersonationMutex guards impersonationTokens
+	impersonationMutex sync.Mutex
+	// impersonationTokens holds the tokens in reuse by user ID
+	impersonationTokens = map[string]impersonationToken{}
+)
+
+// withUserAPI runs fn with a client acting as the given user, or with the local
+// mode client when userID is empty. Local mode requests have no user session,
+// so a personal access token of the user is used (see impersonationTokenFor).
+func withUserAPI(userID string, fn func(api *apiClient) error) error {
+	if userID == "" {
+		return fn(localAPIClient())
+	}
+
+	baseURL, err := siteURL()
+	if err != nil {
+		return err
+	}
+
+	token, err := impersonationTokenFor(userID)
+	if err != nil {
+		return err
+	}
+
+	return fn(&apiClient{
+		client:  &http.Client{Timeout: 2 * time.Minute},
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+	})
+}
+
+// impersonationTokenFor returns a personal access token of the user, reusing
+// the last one generated for impersonationTokenTTL. Tokens are not revoked after
+// each call; their description records when their reuse ends, so that stale
+// tokens are revoked before generating new ones and by the background sweeper,
+// including tokens left behind by processes that stopped.
+func impersonationTokenFor(userID string) (string, error) {
+	impersonationMutex.Lock()
+	defer impersonationMutex.Unlock()
+
+	if t, ok := impersonationTokens[userID]; ok && time.Now().Before(t.reuseUntil) {
+		return t.token, nil
+	}
+	delete(impersonationTokens, userID)
+
+	if err := revokeStaleImpersonationTokens("/users/" + userID + "/tokens"); err != nil {
+		return "", err
+	}
+
+	reuseUntil := time.Now().Add(impersonationTokenTTL).UTC()
+	description := impersonationTokenPrefix + " until " + reuseUntil.Format(time.RFC3339)
+	var token UserAccessToken
+	if err := executeMMCTLJSON(&token, "token", "generate", userID, description); err != nil {
+		return "", fmt.Errorf("error creating impersonation token: %w", err)
+	}
+
+	impersonationTokens[userID] = impersonationToken{token: token.Token, reuseUntil: reuseUntil}
+	return token.Token, nil
+}
+
+// impersonationTokenStale reports whether a token description belongs to an
+// impersonation token that should be revoked at now
+func impersonationTokenStale(description string, now time.Time) bool {
+	rest, ok := strings.CutPrefix(description, impersonationTokenPrefix)
+	if !ok {
+		return false
+	}
+	until, err := time.Parse(time.RFC3339, strings.TrimPrefix(rest, " until "))
+	if err != nil {
+		// Tokens without a reuse time are never reused
+		return true
+	}
+	return now.After(until.Add(impersonationTokenGrace))
+}
+
+// revokeStaleImpersonationTokens revokes the stale impersonation tokens among
+// the tokens listed by the API path
+func revokeStaleImpersonationTokens(path string) error {
+	now := time.Now()
+	var stale []string
+	for page := 0; ; page++ {
+		var tokens []UserAccessToken
+		if err := executeLocalAPI("GET", fmt.Sprintf("%s?page=%d&per_page=200", path, page), nil, &tokens); err != nil {
+			return fmt.Errorf("error listing impersonation tokens: %w", err)
+		}
+		for _, t := range tokens {
+			if impersonationTokenStale(t.Description, now) {
+				stale = append(stale, t.ID)
+			}
+		}
+		if len(tokens) < 200 {
+			break
+		}
+	}
+
+	var failed []string
+	for _, id := range stale {
+		if _, err := executeMMCTL("token", "revoke", id); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", id, err))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("error revoking stale impersonation tokens: %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// runImpersonationTokenSweeper revokes stale impersonation tokens of all users
+// at startup and periodically
+func runImpersonationTokenSweeper() {
+	ticker := time.NewTicker(impersonationTokenTTL)
+	defer ticker.Stop()
+
+	for {
+		if err := revokeStaleImpersonationTokens("/users/tokens"); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to revoke stale impersonation tokens: %v\n", err)
+		}
+		<-ticker.C
+	}
+}
+
+// siteURL returns the configured ServiceSettings.SiteURL
+func siteURL() (string, error) {
+	output, err := executeMMCTL("config", "get", "ServiceSettings.SiteURL")
+	if err != nil {
+		return "", err
+	}
+	url := strings.Trim(strings.TrimSpace(output), "\"")
+	if url == "" {
+		return "", fmt.Errorf("ServiceSettings.SiteURL is not set")
+	}
+	return url, nil
+}
+
+// call sends body as JSON and decodes the JSON response into out
+func (c *apiClient) call(method, path string, body, out interface{}) error {
+	var reqBody io.Reader
+	contentType := ""
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error encoding request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+		contentType = "application/json"
+	}
+
+	return c.request(method, path, contentType, reqBody, out)
+}
+
+// request sends a raw request body and decodes the JSON response into out
+func (c *apiClient) request(method, path, contentType string, body io.Reader, out interface{}) error {
+	req, err := http.NewRequest(method, c.baseURL+"/api/v4"+path, body)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error calling API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading API response: %w", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("API %s %s returned %s: %s", method, path, resp.Status, string(data))
+	}
+
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("error decoding API response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// dataFilePath returns the path of a file used to persist state of the server,
+// creating its directory if needed
+func dataFilePath(name string) (string, error) {
+	dir := os.Getenv("MMCTL_MCP_DATA_DIR")
+	if dir == "" {
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			return "", fmt.Errorf("error finding config directory: %w", err)
+		}
+		dir = filepath.Join(configDir, "mmctl-mcp")
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("error creating data directory: %w", err)
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// loadDataFile decodes a JSON state file into v, leaving v untouched if the
+// file does not exist yet
+func loadDataFile(name string, v interface{}) error {
+	path, err := dataFilePath(name)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", name, err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("error decoding %s: %w", name, err)
+	}
+	return nil
+}
+
+// saveDataFile writes v as JSON to a state file
+func saveDataFile(name string, v interface{}) error {
+	path, err := dataFilePath(name)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding %s: %w", name, err)
+	}
+
+	// Write to a temporary file first so a crash never leaves a partial file
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("error writing %s: %w", name, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("error writing %s: %w", name, err)
+	}
+	return nil
+}
+
+// lockDataFile takes an exclusive lock on a state file that every server
+// process using the same data directory respects, as each MCP client starts
+// its own process. Locks held longer than a minute are assumed to be left
+// behind by a crashed process. The returned function releases the lock.
+func lockDataFile(name string) (func(), error) {
+	path, err := dataFilePath(name + ".lock")
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("error locking %s: %w", name, err)
+		}
+
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > time.Minute {
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for the lock on %s", name)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// newID generates a random identifier for records kept by the server
+func newID() (string, error) {
+	b := make([]byte, 13)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// newJSONToolResponse renders v as indented JSON in a tool response
+func newJSONToolResponse(v interface{}) *mcp_golang.ToolResponse {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err)))
+	}
+	return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(string(data)))
+}
+
 func main() {
 	server := mcp_golang.NewServer(stdio.NewStdioServerTransport())
 
@@ -147,16 +555,66 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := RegisterDirectChannelTools(server); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to register direct channel tools: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := RegisterCategoryTools(server); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to register category tools: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := RegisterModerationTools(server); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to register moderation tools: %v\n", err)
+		os.Exit(1)
+	}
+
 	if err := RegisterUserTools(server); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to register user tools: %v\n", err)
 		os.Exit(1)
 	}
 
+	if err := RegisterGuestTools(server); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to register guest tools: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := RegisterTokenTools(server); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to register token tools: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := RegisterPreferenceTools(server); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to register preference tools: %v\n", err)
+		os.Exit(1)
+	}
+
 	if err := RegisterPostTools(server); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to register post tools: %v\n", err)
 		os.Exit(1)
 	}
 
+	if err := RegisterBroadcastTools(server); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to register broadcast tools: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := RegisterScheduleTools(server); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to register schedule tools: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := RegisterSearchTools(server); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to register search tools: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := RegisterBulkDeleteTools(server); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to register bulk delete tools: %v\n", err)
+		os.Exit(1)
+	}
+
 	if err := RegisterPluginTools(server); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to register plugin tools: %v\n", err)
 		os.Exit(1)
@@ -167,6 +625,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := RegisterInviteTools(server); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to register invite tools: %v\n", err)
+		os.Exit(1)
+	}
+
 	if err := RegisterConfigTools(server); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to register config tools: %v\n", err)
 		os.Exit(1)
@@ -227,6 +690,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Create scheduled posts in the background while serving
+	go runScheduler()
+
+	// Revoke impersonation tokens left behind, also by earlier runs
+	go runImpersonationTokenSweeper()
+
 	// Start the server
 	err = server.Serve()
 	if err != nil {
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestImpersonationTokenStale(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		description string
+		want        bool
+	}{
+		{"release bot token", false},
+		{impersonationTokenPrefix, true},
+		{impersonationTokenPrefix + " until garbage", true},
+		{impersonationTokenPrefix + " until 2026-10-19T12:10:00Z", false},
+		{impersonationTokenPrefix + " until 2026-10-19T11:45:00Z", false},
+		{impersonationTokenPrefix + " until 2026-10-19T11:00:00Z", true},
+	}
+	for _, tt := range tests {
+		if got := impersonationTokenStale(tt.description, now); got != tt.want {
+			t.Errorf("impersonationTokenStale(%q) = %v, want %v", tt.description, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"path"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+// ChannelModerationGetArgs represents arguments for reading channel moderation settings
+type ChannelModerationGetArgs struct {
+	Channel string `json:"channel" jsonschema:"required,description=Channel name or ID (in team:channel format for named channels)"`
+}
+
+// ModerationSetting represents a change to one moderated permission
+type ModerationSetting struct {
+	Permission string `json:"permission" jsonschema:"required,enum=create_post,enum=create_reactions,enum=manage_members,enum=use_channel_mentions,description=Moderated permission"`
+	Members    *bool  `json:"members" jsonschema:"description=Whether members have the permission"`
+	Guests     *bool  `json:"guests" jsonschema:"description=Whether guests have the permission"`
+}
+
+// ChannelModerationSetArgs represents arguments for updating channel moderation settings
+type ChannelModerationSetArgs struct {
+	Channel  string              `json:"channel" jsonschema:"required,description=Channel name or ID (in team:channel format for named channels)"`
+	Settings []ModerationSetting `json:"settings" jsonschema:"required,description=Permissions to change"`
+}
+
+// ChannelModerationPresetArgs represents arguments for applying a moderation preset to many channels
+type ChannelModerationPresetArgs struct {
+	Preset   string   `json:"preset" jsonschema:"required,enum=read_only,enum=default,description=Preset to apply: read_only (only admins post) or default (restore all permissions)"`
+	Channels []string `json:"channels" jsonschema:"description=Channels to update (in team:channel format or channel IDs)"`
+	Team     string   `json:"team" jsonschema:"description=Team whose channels matching pattern are updated (name or ID)"`
+	Pattern  string   `json:"pattern" jsonschema:"description=Channel name pattern, required with team (e.g. announcements-*, or * for every channel)"`
+	DryRun   bool     `json:"dryRun" jsonschema:"description=Only list the channels that would be updated"`
+}
+
+// ChannelModeration represents the moderation state of one permission
+type ChannelModeration struct {
+	Name  string `json:"name"`
+	Roles struct {
+		Members *ModeratedRole `json:"members,omitempty"`
+		Guests  *ModeratedRole `json:"guests,omitempty"`
+	} `json:"roles"`
+}
+
+// ModeratedRole represents whether a role has a moderated permission
+type ModeratedRole struct {
+	Value   bool `json:"value"`
+	Enabled bool `json:"enabled"`
+}
+
+// channelModerationPatch represents a change sent to the moderation API
+type channelModerationPatch struct {
+	Name  string `json:"name"`
+	Roles struct {
+		Members *bool `json:"members,omitempty"`
+		Guests  *bool `json:"guests,omitempty"`
+	} `json:"roles"`
+}
+
+// ModerationPresetResult reports the outcome of applying a preset to one channel
+type ModerationPresetResult struct {
+	Channel string `json:"channel"`
+	Applied bool   `json:"applied"`
+	Error   string `json:"error,omitempty"`
+}
+
+// moderationPresets maps preset names to the settings they apply
+var moderationPresets = map[string][]ModerationSetting{
+	"read_only": {
+		{Permission: "create_post", Members: boolPtr(false), Guests: boolPtr(false)},
+		{Permission: "use_channel_mentions", Members: boolPtr(false), Guests: boolPtr(false)},
+	},
+	"default": {
+		{Permission: "create_post", Members: boolPtr(true), Guests: boolPtr(true)},
+		{Permission: "create_reactions", Members: boolPtr(true), Guests: boolPtr(true)},
+		{Permission: "manage_members", Members: boolPtr(true)},
+		{Permission: "use_channel_mentions", Members: boolPtr(true), Guests: boolPtr(true)},
+	},
+}
+
+// boolPtr returns a pointer to b
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// updateChannelModeration applies moderation settings to a channel and
+// returns the resulting moderation state
+func updateChannelModeration(channelID string, settings []ModerationSetting) ([]ChannelModeration, error) {
+	patches := []channelModerationPatch{}
+	for _, s := range settings {
+		patch := channelModerationPatch{Name: s.Permission}
+		patch.Roles.Members = s.Members
+		// Guests cannot manage channel members
+		if s.Permission != "manage_members" {
+			patch.Roles.Guests = s.Guests
+		}
+		patches = append(patches, patch)
+	}
+
+	var moderations []ChannelModeration
+	if err := executeLocalAPI("PUT", "/channels/"+channelID+"/moderations/patch", patches, &moderations); err != nil {
+		return nil, err
+	}
+	return moderations, nil
+}
+
+// RegisterModerationTools registers all channel moderation related tools
+func RegisterModerationTools(server *mcp_golang.Server) error {
+	// Register channel moderation get tool
+	err := server.RegisterTool("channel_moderation_get", "Show the moderation settings of a channel for members and guests", func(args ChannelModerationGetArgs) (*mcp_golang.ToolResponse, error) {
+		channel, err := getChannel(args.Channel)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		var moderations []ChannelModeration
+		if err := executeLocalAPI("GET", "/channels/"+channel.ID+"/moderations", nil, &moderations); err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		return newJSONToolResponse(moderations), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register channel_moderation_get tool: %v", err)
+	}
+
+	// Register channel moderation set tool
+	err = server.RegisterTool("channel_moderation_set", "Update the moderation settings of a channel for members and guests", func(args ChannelModerationSetArgs) (*mcp_golang.ToolResponse, error) {
+		channel, err := getChannel(args.Channel)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		moderations, err := updateChannelModeration(channel.ID, args.Settings)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		return newJSONToolResponse(moderations), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register channel_moderation_set tool: %v", err)
+	}
+
+	// Register channel moderation preset tool
+	err = server.RegisterTool("channel_moderation_preset", "Apply a moderation preset to many channels, e.g. make announcement channels read-only", func(args ChannelModerationPresetArgs) (*mcp_golang.ToolResponse, error) {
+		settings, ok := moderationPresets[args.Preset]
+		if !ok {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: preset must be read_only or default")), nil
+		}
+
+		targets := append([]string{}, args.Channels...)
+		if args.Team != "" {
+			if args.Pattern == "" {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: pattern is required with team; use * to update every channel of the team")), nil
+			}
+
+			team, err := getTeam(args.Team)
+			if err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+
+			channels, err := listChannels(team.ID)
+			if err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+
+			for _, ch := range channels {
+				if ch.DeleteAt != 0 {
+					continue
+				}
+				matched, err := path.Match(args.Pattern, ch.Name)
+				if err != nil {
+					return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: invalid pattern %q: %v", args.Pattern, err))), nil
+				}
+				if matched {
+					targets = append(targets, team.Name+":"+ch.Name)
+				}
+			}
+		}
+
+		if len(targets) == 0 {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: no channels selected; provide channels, or team and pattern")), nil
+		}
+
+		results := []ModerationPresetResult{}
+		for _, target := range targets {
+			result := ModerationPresetResult{Channel: target}
+			if !args.DryRun {
+				channel, err := getChannel(target)
+				if err == nil {
+					_, err = updateChannelModeration(channel.ID, settings)
+				}
+				if err != nil {
+					result.Error = err.Error()
+					results = append(results, result)
+					continue
+				}
+				result.Applied = true
+			}
+			results = append(results, result)
+		}
+		return newJSONToolResponse(results), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register channel_moderation_preset tool: %v", err)
+	}
+
+	return nil
+}
@@ -1,18 +1,30 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
-	"os/exec"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	mcp_golang "github.com/metoro-io/mcp-golang"
 )
 
 // PostCreateArgs represents arguments for post create command
 type PostCreateArgs struct {
-	Channel  string `json:"channel" jsonschema:"required,description=Channel to post to (in team:channel format for named channels)"`
-	Message  string `json:"message" jsonschema:"required,description=Message text to post"`
-	ReplyTo  string `json:"replyTo" jsonschema:"description=Post ID to reply to"`
-	AsUserID string `json:"asUserId" jsonschema:"required,description=User ID to post as (impersonation)"`
+	Channel  string                 `json:"channel" jsonschema:"required,description=Channel to post to (in team:channel format for named channels)"`
+	Message  string                 `json:"message" jsonschema:"required,description=Message text to post"`
+	ReplyTo  string                 `json:"replyTo" jsonschema:"description=Post ID to reply to"`
+	AsUserID string                 `json:"asUserId" jsonschema:"description=User ID or username to post as (impersonation); must be an existing user"`
+	Files    []string               `json:"files" jsonschema:"description=Local file paths to attach to the post"`
+	Props    map[string]interface{} `json:"props" jsonschema:"description=Post props, e.g. from_webhook or attachments for rich message cards"`
+	Priority string                 `json:"priority" jsonschema:"enum=standard,enum=important,enum=urgent,description=Message priority"`
+	Ack      bool                   `json:"ack" jsonschema:"description=Request acknowledgement from recipients"`
 }
 
 // PostListArgs represents arguments for post list command
@@ -29,37 +41,416 @@ type PostDeleteArgs struct {
 	Permanent bool     `json:"permanent" jsonschema:"description=Permanently delete the post and its contents"`
 }
 
-// RegisterPostTools registers all post related tools
-func RegisterPostTools(server *mcp_golang.Server) error {
-	// Register post create tool
-	err := server.RegisterTool("post_create", "Create a new post", func(args PostCreateArgs) (*mcp_golang.ToolResponse, error) {
-		cmdArgs := []string{"post", "create", "--message", args.Message}
+// PostThreadArgs represents arguments for fetching a thread
+type PostThreadArgs struct {
+	PostID string `json:"postId" jsonschema:"required,description=ID of the thread's root post or of any reply in it"`
+	Format string `json:"format" jsonschema:"enum=markdown,enum=json,description=Output format (default markdown)"`
+}
+
+// PostExportArgs represents arguments for exporting a channel or thread
+type PostExportArgs struct {
+	Channel    string `json:"channel" jsonschema:"description=Channel to export (in team:channel format or channel ID)"`
+	PostID     string `json:"postId" jsonschema:"description=Export the thread containing this post instead of a channel"`
+	Since      string `json:"since" jsonschema:"description=Only export posts created at or after this time (RFC 3339 or YYYY-MM-DD)"`
+	Until      string `json:"until" jsonschema:"description=Only export posts created at or before this time (RFC 3339 or YYYY-MM-DD)"`
+	Format     string `json:"format" jsonschema:"enum=markdown,enum=json,description=Export format (default markdown)"`
+	OutputPath string `json:"outputPath" jsonschema:"description=Write the export to this local file instead of returning it"`
+}
+
+// PostEditArgs represents arguments for editing a post
+type PostEditArgs struct {
+	PostID   string `json:"postId" jsonschema:"required,description=ID of the post to edit"`
+	Message  string `json:"message" jsonschema:"required,description=New message text"`
+	AsUserID string `json:"asUserId" jsonschema:"description=User ID or username editing the post (impersonation); they must be allowed to edit it"`
+}
+
+// PostPinArgs represents arguments for pinning or unpinning a post
+type PostPinArgs struct {
+	PostID   string `json:"postId" jsonschema:"required,description=ID of the post"`
+	AsUserID string `json:"asUserId" jsonschema:"description=User ID or username pinning the post (impersonation)"`
+}
+
+// PostReactionArgs represents arguments for adding or removing a reaction
+type PostReactionArgs struct {
+	PostID    string `json:"postId" jsonschema:"required,description=ID of the post"`
+	EmojiName string `json:"emojiName" jsonschema:"required,description=Emoji name without colons (e.g. white_check_mark)"`
+	AsUserID  string `json:"asUserId" jsonschema:"required,description=User ID or username reacting (impersonation)"`
+}
+
+// PostReactionListArgs represents arguments for listing reactions on a post
+type PostReactionListArgs struct {
+	PostID string `json:"postId" jsonschema:"required,description=ID of the post"`
+}
+
+// Post represents the fields of a Mattermost post used by the tools
+type Post struct {
+	ID        string                 `json:"id"`
+	ChannelID string                 `json:"channel_id"`
+	UserID    string                 `json:"user_id"`
+	RootID    string                 `json:"root_id"`
+	Message   string                 `json:"message"`
+	Type      string                 `json:"type"`
+	CreateAt  int64                  `json:"create_at"`
+	UpdateAt  int64                  `json:"update_at"`
+	DeleteAt  int64                  `json:"delete_at"`
+	IsPinned  bool                   `json:"is_pinned"`
+	Props     map[string]interface{} `json:"props,omitempty"`
+	Metadata  *PostMetadata          `json:"metadata,omitempty"`
+}
 
-		if args.ReplyTo != "" {
-			cmdArgs = append(cmdArgs, "--reply-to", args.ReplyTo)
+// PostMetadata represents the reactions and files attached to a post
+type PostMetadata struct {
+	Reactions []Reaction `json:"reactions,omitempty"`
+	Files     []struct {
+		Name string `json:"name"`
+	} `json:"files,omitempty"`
+}
+
+// Reaction represents an emoji reaction to a post
+type Reaction struct {
+	UserID    string `json:"user_id"`
+	PostID    string `json:"post_id"`
+	EmojiName string `json:"emoji_name"`
+}
+
+// ExportedPost is the export representation of a post
+type ExportedPost struct {
+	ID        string         `json:"id"`
+	RootID    string         `json:"rootId,omitempty"`
+	Author    string         `json:"author"`
+	CreatedAt string         `json:"createdAt"`
+	Message   string         `json:"message"`
+	Reactions map[string]int `json:"reactions,omitempty"`
+	Files     []string       `json:"files,omitempty"`
+}
+
+// resolveImpersonatedUser validates the user a post is created as and
+// returns its ID, or an empty string when not impersonating
+func resolveImpersonatedUser(asUser string) (string, error) {
+	if asUser == "" {
+		return "", nil
+	}
+
+	user, err := getUser(asUser)
+	if err != nil {
+		return "", fmt.Errorf("invalid impersonated user: %w", err)
+	}
+	if user.DeleteAt != 0 {
+		return "", fmt.Errorf("invalid impersonated user: %s is deactivated", user.Username)
+	}
+	return user.ID, nil
+}
+
+// createPost creates a post, optionally impersonating a user. Plain text posts
+// go through mmctl; posts with files, props or priority use the local API.
+func createPost(args PostCreateArgs) (string, error) {
+	if args.Priority != "" && args.Priority != "standard" && args.Priority != "important" && args.Priority != "urgent" {
+		return "", fmt.Errorf("priority must be standard, important or urgent")
+	}
+
+	userID, err := resolveImpersonatedUser(args.AsUserID)
+	if err != nil {
+		return "", err
+	}
+
+	if len(args.Files) > 0 || len(args.Props) > 0 || args.Priority != "" || args.Ack {
+		post, err := createRichPost(args, userID)
+		if err != nil {
+			return "", err
 		}
+		return fmt.Sprintf("Post %s created successfully", post.ID), nil
+	}
+
+	cmdArgs := []string{"post", "create", "--message", args.Message}
+
+	if args.ReplyTo != "" {
+		cmdArgs = append(cmdArgs, "--reply-to", args.ReplyTo)
+	}
+
+	// Add the channel as the last argument
+	cmdArgs = append(cmdArgs, args.Channel)
 
-		// Add the channel as the last argument
-		cmdArgs = append(cmdArgs, args.Channel)
+	return executeMMCTLAsUser(userID, cmdArgs...)
+}
 
-		var output string
+// createRichPost creates a post with attachments, props or priority through
+// the API, as the given user when userID is set
+func createRichPost(args PostCreateArgs, userID string) (*Post, error) {
+	var post *Post
+	err := withUserAPI(userID, func(api *apiClient) error {
 		var err error
-		if args.AsUserID != "" {
-			// For impersonation, we handle the special case directly
-			// Create the command with the --local and --local-user-id flags
-			localArgs := append([]string{"--local", "--local-user-id", args.AsUserID}, cmdArgs...)
-			cmd := exec.Command("mmctl", localArgs...)
-			outputBytes, cmdErr := cmd.CombinedOutput()
-			if cmdErr != nil {
-				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v\nOutput: %s", cmdErr, string(outputBytes)))), nil
+		post, err = createRichPostWithAPI(api, args)
+		return err
+	})
+	return post, err
+}
+
+// createRichPostWithAPI creates a post with attachments, props or priority
+// as the user the client acts for
+func createRichPostWithAPI(api *apiClient, args PostCreateArgs) (*Post, error) {
+	channel, err := getChannel(args.Channel)
+	if err != nil {
+		return nil, err
+	}
+
+	fileIDs := []string{}
+	for _, path := range args.Files {
+		id, err := uploadFile(api, channel.ID, path)
+		if err != nil {
+			return nil, err
+		}
+		fileIDs = append(fileIDs, id)
+	}
+
+	post := map[string]interface{}{
+		"channel_id": channel.ID,
+		"message":    args.Message,
+		"root_id":    args.ReplyTo,
+		"file_ids":   fileIDs,
+	}
+	if len(args.Props) > 0 {
+		post["props"] = args.Props
+	}
+	if args.Priority != "" || args.Ack {
+		priority := args.Priority
+		if priority == "standard" {
+			priority = ""
+		}
+		post["metadata"] = map[string]interface{}{
+			"priority": map[string]interface{}{
+				"priority":      priority,
+				"requested_ack": args.Ack,
+			},
+		}
+	}
+
+	var created Post
+	if err := api.call("POST", "/posts", post, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// uploadFile uploads a local file to a channel and returns the file ID
+func uploadFile(api *apiClient, channelID, path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("error opening %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("channel_id", channelID); err != nil {
+		return "", err
+	}
+	part, err := writer.CreateFormFile("files", filepath.Base(path))
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(part, f); err != nil {
+		return "", fmt.Errorf("error reading %s: %w", path, err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	var resp struct {
+		FileInfos []struct {
+			ID string `json:"id"`
+		} `json:"file_infos"`
+	}
+	if err := api.request("POST", "/files", writer.FormDataContentType(), &body, &resp); err != nil {
+		return "", err
+	}
+	if len(resp.FileInfos) == 0 {
+		return "", fmt.Errorf("no file uploaded for %s", path)
+	}
+	return resp.FileInfos[0].ID, nil
+}
+
+// PostList represents a page of posts returned by the API
+type PostList struct {
+	Order []string        `json:"order"`
+	Posts map[string]Post `json:"posts"`
+}
+
+// fetchChannelPosts pages back through a channel's history and returns its
+// posts created between since and until (milliseconds, zero for no bound),
+// oldest first
+func fetchChannelPosts(channelID string, since, until int64) ([]Post, error) {
+	var posts []Post
+	for page := 0; ; page++ {
+		var list PostList
+		path := fmt.Sprintf("/channels/%s/posts?page=%d&per_page=200", channelID, page)
+		if err := executeLocalAPI("GET", path, nil, &list); err != nil {
+			return nil, err
+		}
+
+		older := false
+		for _, id := range list.Order {
+			post := list.Posts[id]
+			if since > 0 && post.CreateAt < since {
+				older = true
+				continue
 			}
-			output = string(outputBytes)
-		} else {
-			// Use standard executeMMCTL for normal post creation
-			output, err = executeMMCTL(cmdArgs...)
-			if err != nil {
-				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			if until > 0 && post.CreateAt > until {
+				continue
+			}
+			posts = append(posts, post)
+		}
+
+		if older || len(list.Order) < 200 {
+			break
+		}
+	}
+
+	// The API returns newest posts first
+	for i, j := 0, len(posts)-1; i < j; i, j = i+1, j-1 {
+		posts[i], posts[j] = posts[j], posts[i]
+	}
+	return posts, nil
+}
+
+// fetchThread returns the posts of the thread containing postID, oldest first
+func fetchThread(postID string) ([]Post, error) {
+	var list PostList
+	if err := executeLocalAPI("GET", "/posts/"+postID+"/thread", nil, &list); err != nil {
+		return nil, err
+	}
+
+	if len(list.Posts) == 0 {
+		return nil, fmt.Errorf("thread for post %s not found", postID)
+	}
+
+	posts := make([]Post, 0, len(list.Posts))
+	for _, p := range list.Posts {
+		posts = append(posts, p)
+	}
+	sort.Slice(posts, func(i, j int) bool { return posts[i].CreateAt < posts[j].CreateAt })
+	return posts, nil
+}
+
+// parseMillis parses an RFC 3339 time or a YYYY-MM-DD date into milliseconds,
+// returning zero for an empty string
+func parseMillis(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		if t, err = time.Parse("2006-01-02", value); err != nil {
+			return 0, fmt.Errorf("invalid time %q, expected RFC 3339 or YYYY-MM-DD", value)
+		}
+	}
+	return t.UnixMilli(), nil
+}
+
+// exportPosts renders posts as JSON or Markdown with author usernames resolved
+func exportPosts(title string, posts []Post, format string) (string, error) {
+	ids := []string{}
+	seen := map[string]bool{}
+	for _, p := range posts {
+		if !seen[p.UserID] {
+			seen[p.UserID] = true
+			ids = append(ids, p.UserID)
+		}
+	}
+	users, err := getUsersByIDs(ids)
+	if err != nil {
+		return "", err
+	}
+
+	exported := []ExportedPost{}
+	for _, p := range posts {
+		author := p.UserID
+		if u, ok := users[p.UserID]; ok {
+			author = u.Username
+		}
+
+		e := ExportedPost{ID: p.ID, RootID: p.RootID, Author: author, CreatedAt: formatMillis(p.CreateAt), Message: p.Message}
+		if p.Metadata != nil {
+			for _, r := range p.Metadata.Reactions {
+				if e.Reactions == nil {
+					e.Reactions = map[string]int{}
+				}
+				e.Reactions[r.EmojiName]++
+			}
+			for _, f := range p.Metadata.Files {
+				e.Files = append(e.Files, f.Name)
+			}
+		}
+		exported = append(exported, e)
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(exported, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	for _, e := range exported {
+		indent := ""
+		if e.RootID != "" {
+			indent = "> "
+		}
+		fmt.Fprintf(&b, "%s**%s** (%s):\n", indent, e.Author, e.CreatedAt)
+		for _, line := range strings.Split(e.Message, "\n") {
+			fmt.Fprintf(&b, "%s%s\n", indent, line)
+		}
+		if len(e.Files) > 0 {
+			fmt.Fprintf(&b, "%sFiles: %s\n", indent, strings.Join(e.Files, ", "))
+		}
+		if len(e.Reactions) > 0 {
+			names := make([]string, 0, len(e.Reactions))
+			for name := range e.Reactions {
+				names = append(names, name)
 			}
+			sort.Strings(names)
+			reactions := []string{}
+			for _, name := range names {
+				reactions = append(reactions, fmt.Sprintf(":%s: %d", name, e.Reactions[name]))
+			}
+			fmt.Fprintf(&b, "%sReactions: %s\n", indent, strings.Join(reactions, " "))
+		}
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+// latestUserPost returns the most recent post in a channel written by a user,
+// skipping system messages such as joins and leaves, or nil if there is none
+func latestUserPost(channelID string) (*Post, error) {
+	for page := 0; ; page++ {
+		var list PostList
+		path := fmt.Sprintf("/channels/%s/posts?page=%d&per_page=200", channelID, page)
+		if err := executeLocalAPI("GET", path, nil, &list); err != nil {
+			return nil, err
+		}
+
+		// The API returns newest posts first
+		for _, id := range list.Order {
+			if post := list.Posts[id]; post.Type == "" {
+				return &post, nil
+			}
+		}
+		if len(list.Order) < 200 {
+			return nil, nil
+		}
+	}
+}
+
+// RegisterPostTools registers all post related tools
+func RegisterPostTools(server *mcp_golang.Server) error {
+	// Register post create tool
+	err := server.RegisterTool("post_create", "Create a new post", func(args PostCreateArgs) (*mcp_golang.ToolResponse, error) {
+		output, err := createPost(args)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
 		}
 		if output == "" {
 			output = "Post created successfully"
@@ -123,5 +514,213 @@ func RegisterPostTools(server *mcp_golang.Server) error {
 		return fmt.Errorf("failed to register post_delete tool: %v", err)
 	}
 
+	// Register post thread tool
+	err = server.RegisterTool("post_thread", "Fetch a full thread given its root post or any reply", func(args PostThreadArgs) (*mcp_golang.ToolResponse, error) {
+		posts, err := fetchThread(args.PostID)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		output, err := exportPosts(fmt.Sprintf("Thread %s", posts[0].ID), posts, args.Format)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(output)), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register post_thread tool: %v", err)
+	}
+
+	// Register post export tool
+	err = server.RegisterTool("post_export", "Export a channel or thread over a time range to Markdown or JSON", func(args PostExportArgs) (*mcp_golang.ToolResponse, error) {
+		if (args.Channel == "") == (args.PostID == "") {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: exactly one of channel or postId must be provided")), nil
+		}
+
+		since, err := parseMillis(args.Since)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		until, err := parseMillis(args.Until)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		var title string
+		var posts []Post
+		if args.PostID != "" {
+			thread, err := fetchThread(args.PostID)
+			if err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+			for _, p := range thread {
+				if (since == 0 || p.CreateAt >= since) && (until == 0 || p.CreateAt <= until) {
+					posts = append(posts, p)
+				}
+			}
+			title = fmt.Sprintf("Thread %s", thread[0].ID)
+		} else {
+			channel, err := getChannel(args.Channel)
+			if err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+			if posts, err = fetchChannelPosts(channel.ID, since, until); err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+			title = channel.DisplayName
+		}
+
+		export, err := exportPosts(title, posts, args.Format)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		if args.OutputPath != "" {
+			if err := os.WriteFile(args.OutputPath, []byte(export), 0o600); err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Exported %d posts to %s", len(posts), args.OutputPath))), nil
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(export)), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register post_export tool: %v", err)
+	}
+
+	// Register post edit tool
+	err = server.RegisterTool("post_edit", "Edit the message of an existing post. mmctl has no command for this, so with asUserId the API is called as that user through a reused personal access token", func(args PostEditArgs) (*mcp_golang.ToolResponse, error) {
+		userID, err := resolveImpersonatedUser(args.AsUserID)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		// The server checks the user may edit the post
+		var updated Post
+		err = withUserAPI(userID, func(api *apiClient) error {
+			return api.call("PUT", "/posts/"+args.PostID+"/patch", map[string]interface{}{"message": args.Message}, &updated)
+		})
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Post %s updated successfully", updated.ID))), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register post_edit tool: %v", err)
+	}
+
+	// Register post pin tool
+	err = server.RegisterTool("post_pin", "Pin a post to its channel. mmctl has no command for this, so with asUserId the API is called as that user through a reused personal access token", func(args PostPinArgs) (*mcp_golang.ToolResponse, error) {
+		userID, err := resolveImpersonatedUser(args.AsUserID)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		err = withUserAPI(userID, func(api *apiClient) error {
+			return api.call("POST", "/posts/"+args.PostID+"/pin", nil, nil)
+		})
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Post pinned successfully")), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register post_pin tool: %v", err)
+	}
+
+	// Register post unpin tool
+	err = server.RegisterTool("post_unpin", "Unpin a post from its channel. mmctl has no command for this, so with asUserId the API is called as that user through a reused personal access token", func(args PostPinArgs) (*mcp_golang.ToolResponse, error) {
+		userID, err := resolveImpersonatedUser(args.AsUserID)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		err = withUserAPI(userID, func(api *apiClient) error {
+			return api.call("POST", "/posts/"+args.PostID+"/unpin", nil, nil)
+		})
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Post unpinned successfully")), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register post_unpin tool: %v", err)
+	}
+
+	// Register post reaction add tool
+	err = server.RegisterTool("post_reaction_add", "Add an emoji reaction to a post on behalf of a user. mmctl has no command for this, so the API is called as that user through a reused personal access token", func(args PostReactionArgs) (*mcp_golang.ToolResponse, error) {
+		if args.AsUserID == "" {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: asUserId is required")), nil
+		}
+		userID, err := resolveImpersonatedUser(args.AsUserID)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		reaction := Reaction{UserID: userID, PostID: args.PostID, EmojiName: strings.Trim(args.EmojiName, ":")}
+		err = withUserAPI(userID, func(api *apiClient) error {
+			return api.call("POST", "/reactions", reaction, nil)
+		})
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Reaction added successfully")), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register post_reaction_add tool: %v", err)
+	}
+
+	// Register post reaction remove tool
+	err = server.RegisterTool("post_reaction_remove", "Remove a user's emoji reaction from a post. mmctl has no command for this, so the API is called as that user through a reused personal access token", func(args PostReactionArgs) (*mcp_golang.ToolResponse, error) {
+		if args.AsUserID == "" {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: asUserId is required")), nil
+		}
+		userID, err := resolveImpersonatedUser(args.AsUserID)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		path := fmt.Sprintf("/users/%s/posts/%s/reactions/%s", userID, args.PostID, strings.Trim(args.EmojiName, ":"))
+		err = withUserAPI(userID, func(api *apiClient) error {
+			return api.call("DELETE", path, nil, nil)
+		})
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Reaction removed successfully")), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register post_reaction_remove tool: %v", err)
+	}
+
+	// Register post reaction list tool
+	err = server.RegisterTool("post_reaction_list", "List the reactions on a post with the users who reacted", func(args PostReactionListArgs) (*mcp_golang.ToolResponse, error) {
+		var reactions []Reaction
+		if err := executeLocalAPI("GET", "/posts/"+args.PostID+"/reactions", nil, &reactions); err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		ids := []string{}
+		for _, r := range reactions {
+			ids = append(ids, r.UserID)
+		}
+		users, err := getUsersByIDs(ids)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		byEmoji := map[string][]string{}
+		for _, r := range reactions {
+			name := r.UserID
+			if u, ok := users[r.UserID]; ok {
+				name = u.Username
+			}
+			byEmoji[r.EmojiName] = append(byEmoji[r.EmojiName], name)
+		}
+		return newJSONToolResponse(byEmoji), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register post_reaction_list tool: %v", err)
+	}
+
 	return nil
 }
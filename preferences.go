@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+// PreferenceListArgs represents arguments for user preference list command
+type PreferenceListArgs struct {
+	Users    []string `json:"users" jsonschema:"description=Users to list preferences for (username, email, or ID)"`
+	Team     string   `json:"team" jsonschema:"description=List preferences for all members of this team (name or ID)"`
+	Category string   `json:"category" jsonschema:"description=Only list preferences in this category (e.g. display_settings, notifications)"`
+}
+
+// PreferenceGetArgs represents arguments for user preference get command
+type PreferenceGetArgs struct {
+	Users    []string `json:"users" jsonschema:"description=Users to get the preference for (username, email, or ID)"`
+	Team     string   `json:"team" jsonschema:"description=Get the preference for all members of this team (name or ID)"`
+	Category string   `json:"category" jsonschema:"required,description=Preference category (e.g. display_settings)"`
+	Name     string   `json:"name" jsonschema:"required,description=Preference name (e.g. collapsed_reply_threads)"`
+}
+
+// PreferenceSetArgs represents arguments for user preference update command
+type PreferenceSetArgs struct {
+	Users    []string `json:"users" jsonschema:"description=Users to set the preference for (username, email, or ID)"`
+	Team     string   `json:"team" jsonschema:"description=Set the preference for all members of this team (name or ID)"`
+	Category string   `json:"category" jsonschema:"required,description=Preference category (e.g. display_settings)"`
+	Name     string   `json:"name" jsonschema:"required,description=Preference name (e.g. collapsed_reply_threads)"`
+	Value    string   `json:"value" jsonschema:"required,description=Preference value (e.g. on)"`
+}
+
+// PreferenceDeleteArgs represents arguments for user preference delete command
+type PreferenceDeleteArgs struct {
+	Users    []string `json:"users" jsonschema:"description=Users to delete the preference for (username, email, or ID)"`
+	Team     string   `json:"team" jsonschema:"description=Delete the preference for all members of this team (name or ID)"`
+	Category string   `json:"category" jsonschema:"required,description=Preference category"`
+	Name     string   `json:"name" jsonschema:"required,description=Preference name"`
+}
+
+// preferenceTargets resolves the users a preference command applies to,
+// expanding a team into the usernames of its active members
+func preferenceTargets(users []string, team string) ([]string, error) {
+	targets := append([]string{}, users...)
+
+	if team != "" {
+		members, err := listUsers(team)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range members {
+			if m.DeleteAt == 0 {
+				targets = append(targets, m.Username)
+			}
+		}
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("either users or team must be provided")
+	}
+	return targets, nil
+}
+
+// RegisterPreferenceTools registers all user preference related tools
+func RegisterPreferenceTools(server *mcp_golang.Server) error {
+	// Register preference list tool
+	err := server.RegisterTool("preference_list", "List user preferences", func(args PreferenceListArgs) (*mcp_golang.ToolResponse, error) {
+		targets, err := preferenceTargets(args.Users, args.Team)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		cmdArgs := []string{"user", "preference", "list"}
+
+		if args.Category != "" {
+			cmdArgs = append(cmdArgs, "--category", args.Category)
+		}
+
+		cmdArgs = append(cmdArgs, targets...)
+
+		output, err := executeMMCTL(cmdArgs...)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		if output == "" {
+			output = "No preferences found"
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(output)), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register preference_list tool: %v", err)
+	}
+
+	// Register preference get tool
+	err = server.RegisterTool("preference_get", "Get a user preference", func(args PreferenceGetArgs) (*mcp_golang.ToolResponse, error) {
+		targets, err := preferenceTargets(args.Users, args.Team)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		cmdArgs := []string{"user", "preference", "get", "--category", args.Category, "--name", args.Name}
+		cmdArgs = append(cmdArgs, targets...)
+
+		output, err := executeMMCTL(cmdArgs...)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		if output == "" {
+			output = "Preference not set"
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(output)), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register preference_get tool: %v", err)
+	}
+
+	// Register preference set tool
+	err = server.RegisterTool("preference_set", "Set a user preference", func(args PreferenceSetArgs) (*mcp_golang.ToolResponse, error) {
+		targets, err := preferenceTargets(args.Users, args.Team)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		cmdArgs := []string{"user", "preference", "update", "--category", args.Category, "--name", args.Name, "--value", args.Value}
+		cmdArgs = append(cmdArgs, targets...)
+
+		output, err := executeMMCTL(cmdArgs...)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		if output == "" {
+			output = fmt.Sprintf("Preference set for %d users successfully", len(targets))
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(output)), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register preference_set tool: %v", err)
+	}
+
+	// Register preference delete tool
+	err = server.RegisterTool("preference_delete", "Delete a user preference", func(args PreferenceDeleteArgs) (*mcp_golang.ToolResponse, error) {
+		targets, err := preferenceTargets(args.Users, args.Team)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		cmdArgs := []string{"user", "preference", "delete", "--category", args.Category, "--name", args.Name}
+		cmdArgs = append(cmdArgs, targets...)
+
+		output, err := executeMMCTL(cmdArgs...)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		if output == "" {
+			output = fmt.Sprintf("Preference deleted for %d users successfully", len(targets))
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(output)), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register preference_delete tool: %v", err)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"strings"
 
 	mcp_golang "github.com/metoro-io/mcp-golang"
 )
@@ -16,6 +17,102 @@ type RoleMemberArgs struct {
 	Users []string `json:"users" jsonschema:"required,description=Users to demote to member (username, email, or user ID)"`
 }
 
+// RoleTeamSetArgs represents arguments for setting team scheme roles
+type RoleTeamSetArgs struct {
+	Team  string   `json:"team" jsonschema:"required,description=Team name or ID"`
+	Users []string `json:"users" jsonschema:"required,description=Team members to update (username, email, or user ID)"`
+	Role  string   `json:"role" jsonschema:"required,enum=admin,enum=member,description=Team role to set: admin (team admin) or member"`
+}
+
+// RoleChannelSetArgs represents arguments for setting channel scheme roles
+type RoleChannelSetArgs struct {
+	Channel string   `json:"channel" jsonschema:"required,description=Channel name or ID (in team:channel format for named channels)"`
+	Users   []string `json:"users" jsonschema:"required,description=Channel members to update (username, email, or user ID)"`
+	Role    string   `json:"role" jsonschema:"required,enum=admin,enum=member,description=Channel role to set: admin (channel admin) or member"`
+}
+
+// RoleCustomAssignArgs represents arguments for assigning custom system roles
+type RoleCustomAssignArgs struct {
+	Users  []string `json:"users" jsonschema:"required,description=Users to update (username, email, or user ID)"`
+	Roles  []string `json:"roles" jsonschema:"required,description=Role names to assign (e.g. system_user_manager)"`
+	Remove bool     `json:"remove" jsonschema:"description=Remove the roles instead of assigning them"`
+}
+
+// RoleChange reports the roles of a user before and after an update,
+// so the change can be reverted
+type RoleChange struct {
+	User     string `json:"user"`
+	Scope    string `json:"scope"`
+	Previous string `json:"previous"`
+	New      string `json:"new"`
+	Error    string `json:"error,omitempty"`
+}
+
+// SchemeRoles represents the scheme role flags of a team or channel member
+type SchemeRoles struct {
+	SchemeAdmin bool `json:"scheme_admin"`
+	SchemeUser  bool `json:"scheme_user"`
+	SchemeGuest bool `json:"scheme_guest"`
+}
+
+// updateRoleList adds or removes roles from a space separated role list
+func updateRoleList(current string, roles []string, remove bool) string {
+	fields := strings.Fields(current)
+	for _, role := range roles {
+		found := -1
+		for i, f := range fields {
+			if f == role {
+				found = i
+				break
+			}
+		}
+
+		if remove && found >= 0 {
+			fields = append(fields[:found], fields[found+1:]...)
+		} else if !remove && found < 0 {
+			fields = append(fields, role)
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// setMemberSchemeRoles updates the scheme roles of a team or channel member.
+// membersPath is the API path of the team or channel members collection.
+func setMemberSchemeRoles(membersPath, scope, term, role string) RoleChange {
+	change := RoleChange{User: term, Scope: scope}
+
+	user, err := getUser(term)
+	if err != nil {
+		change.Error = err.Error()
+		return change
+	}
+	change.User = user.Username
+
+	var member struct {
+		SchemeRoles
+		Roles string `json:"roles"`
+	}
+	if err := executeLocalAPI("GET", membersPath+"/"+user.ID, nil, &member); err != nil {
+		change.Error = err.Error()
+		return change
+	}
+	change.Previous = member.Roles
+
+	roles := member.SchemeRoles
+	roles.SchemeAdmin = role == "admin"
+	if err := executeLocalAPI("PUT", membersPath+"/"+user.ID+"/schemeRoles", roles, nil); err != nil {
+		change.Error = err.Error()
+		return change
+	}
+
+	if err := executeLocalAPI("GET", membersPath+"/"+user.ID, nil, &member); err != nil {
+		change.Error = err.Error()
+		return change
+	}
+	change.New = member.Roles
+	return change
+}
+
 // RegisterRoleTools registers all role related tools
 func RegisterRoleTools(server *mcp_golang.Server) error {
 	// Register roles system-admin tool
@@ -54,5 +151,77 @@ func RegisterRoleTools(server *mcp_golang.Server) error {
 		return fmt.Errorf("failed to register role_member tool: %v", err)
 	}
 
+	// Register team role tool
+	err = server.RegisterTool("role_team_set", "Set the team admin or member scheme role for team members", func(args RoleTeamSetArgs) (*mcp_golang.ToolResponse, error) {
+		if args.Role != "admin" && args.Role != "member" {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: role must be admin or member")), nil
+		}
+
+		team, err := getTeam(args.Team)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		changes := []RoleChange{}
+		for _, user := range args.Users {
+			changes = append(changes, setMemberSchemeRoles("/teams/"+team.ID+"/members", "team:"+team.Name, user, args.Role))
+		}
+		return newJSONToolResponse(changes), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register role_team_set tool: %v", err)
+	}
+
+	// Register channel role tool
+	err = server.RegisterTool("role_channel_set", "Set the channel admin or member scheme role for channel members", func(args RoleChannelSetArgs) (*mcp_golang.ToolResponse, error) {
+		if args.Role != "admin" && args.Role != "member" {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: role must be admin or member")), nil
+		}
+
+		channel, err := getChannel(args.Channel)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		changes := []RoleChange{}
+		for _, user := range args.Users {
+			changes = append(changes, setMemberSchemeRoles("/channels/"+channel.ID+"/members", "channel:"+args.Channel, user, args.Role))
+		}
+		return newJSONToolResponse(changes), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register role_channel_set tool: %v", err)
+	}
+
+	// Register custom role assignment tool
+	err = server.RegisterTool("role_custom_assign", "Assign or remove custom system roles for users", func(args RoleCustomAssignArgs) (*mcp_golang.ToolResponse, error) {
+		changes := []RoleChange{}
+		for _, term := range args.Users {
+			change := RoleChange{User: term, Scope: "system"}
+
+			user, err := getUser(term)
+			if err != nil {
+				change.Error = err.Error()
+				changes = append(changes, change)
+				continue
+			}
+			change.User = user.Username
+			change.Previous = user.Roles
+
+			roles := updateRoleList(user.Roles, args.Roles, args.Remove)
+			if err := executeLocalAPI("PUT", "/users/"+user.ID+"/roles", map[string]string{"roles": roles}, nil); err != nil {
+				change.Error = err.Error()
+				changes = append(changes, change)
+				continue
+			}
+			change.New = roles
+			changes = append(changes, change)
+		}
+		return newJSONToolResponse(changes), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register role_custom_assign tool: %v", err)
+	}
+
 	return nil
 }
@@ -0,0 +1,431 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+// PostScheduleArgs represents arguments for scheduling a post
+type PostScheduleArgs struct {
+	Channel  string `json:"channel" jsonschema:"required,description=Channel to post to (in team:channel format for named channels)"`
+	Message  string `json:"message" jsonschema:"required,description=Message text to post"`
+	AsUserID string `json:"asUserId" jsonschema:"description=User ID or username to post as (impersonation)"`
+	At       string `json:"at" jsonschema:"description=Time to post once (RFC 3339, e.g. 2025-06-01T09:00:00Z)"`
+	Cron     string `json:"cron" jsonschema:"description=Recurring schedule as a cron expression (minute hour day-of-month month day-of-week, e.g. '0 9 * * 1')"`
+	Timezone string `json:"timezone" jsonschema:"description=IANA timezone the cron expression is evaluated in (default server local time)"`
+}
+
+// PostScheduleListArgs represents arguments for listing scheduled posts
+type PostScheduleListArgs struct {
+	Channel string `json:"channel" jsonschema:"description=Only list posts scheduled for this channel"`
+}
+
+// PostScheduleCancelArgs represents arguments for cancelling a scheduled post
+type PostScheduleCancelArgs struct {
+	ID string `json:"id" jsonschema:"required,description=ID of the scheduled post to cancel"`
+}
+
+// ScheduledPost is a post the server creates at a later time
+type ScheduledPost struct {
+	ID        string `json:"id"`
+	Channel   string `json:"channel"`
+	Message   string `json:"message"`
+	AsUserID  string `json:"asUserId,omitempty"`
+	Cron      string `json:"cron,omitempty"`
+	Timezone  string `json:"timezone,omitempty"`
+	NextRun   string `json:"nextRun"`
+	CreatedAt string `json:"createdAt"`
+	Status    string `json:"status,omitempty"`
+	ClaimedAt string `json:"claimedAt,omitempty"`
+	LastRun   string `json:"lastRun,omitempty"`
+	LastError string `json:"lastError,omitempty"`
+}
+
+// Statuses of one-off scheduled posts that are no longer waiting to run
+const (
+	scheduleRunning = "running"
+	scheduleFailed  = "failed"
+)
+
+// scheduleClaimTimeout is how long a one-off post may stay running before the
+// process sending it is assumed to have died
+const scheduleClaimTimeout = 10 * time.Minute
+
+// cronSchedule is a parsed cron expression, holding the allowed values of each field
+type cronSchedule struct {
+	minutes, hours, days, months, weekdays map[int]bool
+	anyDay, anyWeekday                     bool
+}
+
+// parseCronField parses one cron field such as "*", "*/15", "1-5" or "0,30"
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	values := map[int]bool{}
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if rangePart, stepPart, ok := strings.Cut(part, "/"); ok {
+			s, err := strconv.Atoi(stepPart)
+			if err != nil || s <= 0 {
+				return nil, fmt.Errorf("invalid step in %q", field)
+			}
+			step = s
+			part = rangePart
+		}
+
+		lo, hi := min, max
+		if part != "*" {
+			loPart, hiPart, isRange := strings.Cut(part, "-")
+			var err error
+			if lo, err = strconv.Atoi(loPart); err != nil {
+				return nil, fmt.Errorf("invalid value in %q", field)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(hiPart); err != nil {
+					return nil, fmt.Errorf("invalid value in %q", field)
+				}
+			} else if step > 1 {
+				hi = max
+			}
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("value out of range in %q", field)
+		}
+		for v := lo; v <= hi; v += step {
+			values[v] = true
+		}
+	}
+	return values, nil
+}
+
+// parseCron parses a five field cron expression
+func parseCron(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression must have 5 fields, got %d", len(fields))
+	}
+
+	var err error
+	c := &cronSchedule{anyDay: fields[2] == "*", anyWeekday: fields[4] == "*"}
+	if c.minutes, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, err
+	}
+	if c.hours, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, err
+	}
+	if c.days, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, err
+	}
+	if c.months, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, err
+	}
+	if c.weekdays, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, err
+	}
+	// Both 0 and 7 mean Sunday
+	if c.weekdays[7] {
+		c.weekdays[0] = true
+	}
+	return c, nil
+}
+
+// next returns the first time after t matching the schedule. It steps through
+// absolute time so that wall clock times skipped when clocks spring forward
+// never match, and times repeated when clocks fall back match only once.
+func (c *cronSchedule) next(t time.Time) (time.Time, error) {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		// As in standard cron, when both day fields are restricted either may match
+		dayMatch := c.days[t.Day()]
+		weekdayMatch := c.weekdays[int(t.Weekday())]
+		var matches bool
+		switch {
+		case c.anyDay && c.anyWeekday:
+			matches = true
+		case c.anyDay:
+			matches = weekdayMatch
+		case c.anyWeekday:
+			matches = dayMatch
+		default:
+			matches = dayMatch || weekdayMatch
+		}
+
+		if !matches || !c.months[int(t.Month())] || !c.hours[t.Hour()] {
+			// Skip to the start of the next hour
+			t = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+			continue
+		}
+		if !c.minutes[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		// When clocks fall back the wall clock repeats; only its first occurrence matches
+		if first := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, t.Location()); first.Before(t) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("cron expression never matches")
+}
+
+// nextCronRun computes the next run of a cron schedule after t
+func nextCronRun(expr, timezone string, t time.Time) (time.Time, error) {
+	c, err := parseCron(expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	loc := time.Local
+	if timezone != "" {
+		if loc, err = time.LoadLocation(timezone); err != nil {
+			return time.Time{}, fmt.Errorf("invalid timezone: %w", err)
+		}
+	}
+
+	next, err := c.next(t.In(loc))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return next.UTC(), nil
+}
+
+// loadSchedules reads the scheduled posts
+func loadSchedules() ([]ScheduledPost, error) {
+	schedules := []ScheduledPost{}
+	if err := loadDataFile("schedules.json", &schedules); err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// updateSchedules applies update to the scheduled posts while holding the
+// schedules file lock, and saves the result
+func updateSchedules(update func([]ScheduledPost) ([]ScheduledPost, error)) error {
+	unlock, err := lockDataFile("schedules.json")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	schedules, err := loadSchedules()
+	if err != nil {
+		return err
+	}
+	if schedules, err = update(schedules); err != nil {
+		return err
+	}
+	return saveDataFile("schedules.json", schedules)
+}
+
+// claimDueSchedules returns the scheduled posts that are due, first advancing
+// recurring ones to their next run and marking one-off ones as running so
+// that no other server process sends them too. One-off posts left running by a
+// process that died are marked failed rather than sent again, as they may
+// have been created already.
+func claimDueSchedules(now time.Time) ([]ScheduledPost, error) {
+	var due []ScheduledPost
+	err := updateSchedules(func(schedules []ScheduledPost) ([]ScheduledPost, error) {
+		for i, s := range schedules {
+			if s.Status == scheduleRunning {
+				claimedAt, err := time.Parse(time.RFC3339, s.ClaimedAt)
+				if err != nil || now.Sub(claimedAt) > scheduleClaimTimeout {
+					schedules[i].Status = scheduleFailed
+					schedules[i].LastError = "interrupted while posting; check the channel before scheduling it again"
+				}
+				continue
+			}
+
+			next, err := time.Parse(time.RFC3339, s.NextRun)
+			if err != nil || next.After(now) || s.Status != "" {
+				continue
+			}
+			due = append(due, s)
+
+			if s.Cron == "" {
+				schedules[i].Status = scheduleRunning
+				schedules[i].ClaimedAt = now.UTC().Format(time.RFC3339)
+				continue
+			}
+			next, err = nextCronRun(s.Cron, s.Timezone, now)
+			if err != nil {
+				schedules[i].Status = scheduleFailed
+				schedules[i].LastError = err.Error()
+				continue
+			}
+			schedules[i].NextRun = next.Format(time.RFC3339)
+		}
+		return schedules, nil
+	})
+	return due, err
+}
+
+// runDueSchedules creates the scheduled posts that are due and records the
+// outcome. Sent one-off posts are removed; failed ones are kept with their error.
+func runDueSchedules(now time.Time) error {
+	due, err := claimDueSchedules(now)
+	if err != nil || len(due) == 0 {
+		return err
+	}
+
+	results := map[string]string{}
+	for _, s := range due {
+		results[s.ID] = ""
+		if _, err := createPost(PostCreateArgs{Channel: s.Channel, Message: s.Message, AsUserID: s.AsUserID}); err != nil {
+			results[s.ID] = err.Error()
+			fmt.Fprintf(os.Stderr, "Failed to create scheduled post %s: %v\n", s.ID, err)
+		}
+	}
+
+	lastRun := now.UTC().Format(time.RFC3339)
+	return updateSchedules(func(schedules []ScheduledPost) ([]ScheduledPost, error) {
+		remaining := []ScheduledPost{}
+		for _, s := range schedules {
+			postErr, ran := results[s.ID]
+			if !ran {
+				remaining = append(remaining, s)
+				continue
+			}
+
+			s.LastRun = lastRun
+			s.LastError = postErr
+			s.ClaimedAt = ""
+			if s.Cron == "" {
+				if postErr == "" {
+					continue
+				}
+				s.Status = scheduleFailed
+			}
+			remaining = append(remaining, s)
+		}
+		return remaining, nil
+	})
+}
+
+// runScheduler creates scheduled posts as they become due. It never returns.
+func runScheduler() {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		if err := runDueSchedules(time.Now()); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to run scheduled posts: %v\n", err)
+		}
+		<-ticker.C
+	}
+}
+
+// RegisterScheduleTools registers all scheduled post related tools
+func RegisterScheduleTools(server *mcp_golang.Server) error {
+	// Register post schedule tool
+	err := server.RegisterTool("post_schedule", "Schedule a post for a future time or on a recurring cron schedule", func(args PostScheduleArgs) (*mcp_golang.ToolResponse, error) {
+		if (args.At == "") == (args.Cron == "") {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: exactly one of at or cron must be provided")), nil
+		}
+
+		// Validate the channel and user now rather than when the post is due
+		if _, err := getChannel(args.Channel); err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		if _, err := resolveImpersonatedUser(args.AsUserID); err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		now := time.Now()
+		var next time.Time
+		if args.At != "" {
+			at, err := time.Parse(time.RFC3339, args.At)
+			if err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: invalid time: %v", err))), nil
+			}
+			if !at.After(now) {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: time must be in the future")), nil
+			}
+			next = at.UTC()
+		} else {
+			var err error
+			if next, err = nextCronRun(args.Cron, args.Timezone, now); err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+		}
+
+		id, err := newID()
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		scheduled := ScheduledPost{
+			ID:        id,
+			Channel:   args.Channel,
+			Message:   args.Message,
+			AsUserID:  args.AsUserID,
+			Cron:      args.Cron,
+			Timezone:  args.Timezone,
+			NextRun:   next.Format(time.RFC3339),
+			CreatedAt: now.UTC().Format(time.RFC3339),
+		}
+
+		err = updateSchedules(func(schedules []ScheduledPost) ([]ScheduledPost, error) {
+			return append(schedules, scheduled), nil
+		})
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		return newJSONToolResponse(scheduled), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register post_schedule tool: %v", err)
+	}
+
+	// Register post schedule list tool
+	err = server.RegisterTool("post_schedule_list", "List scheduled posts, including failed one-off posts with their error", func(args PostScheduleListArgs) (*mcp_golang.ToolResponse, error) {
+		schedules, err := loadSchedules()
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		filtered := []ScheduledPost{}
+		for _, s := range schedules {
+			if args.Channel == "" || args.Channel == s.Channel {
+				filtered = append(filtered, s)
+			}
+		}
+		sort.Slice(filtered, func(i, j int) bool { return filtered[i].NextRun < filtered[j].NextRun })
+		return newJSONToolResponse(filtered), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register post_schedule_list tool: %v", err)
+	}
+
+	// Register post schedule cancel tool
+	err = server.RegisterTool("post_schedule_cancel", "Cancel a scheduled post or remove a failed one", func(args PostScheduleCancelArgs) (*mcp_golang.ToolResponse, error) {
+		err := updateSchedules(func(schedules []ScheduledPost) ([]ScheduledPost, error) {
+			remaining := []ScheduledPost{}
+			for _, s := range schedules {
+				if s.ID != args.ID {
+					remaining = append(remaining, s)
+				}
+			}
+			if len(remaining) == len(schedules) {
+				return nil, fmt.Errorf("scheduled post %s not found", args.ID)
+			}
+			return remaining, nil
+		})
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Scheduled post cancelled successfully")), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register post_schedule_cancel tool: %v", err)
+	}
+
+	return nil
+}
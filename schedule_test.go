@@ -0,0 +1,221 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronField(t *testing.T) {
+	tests := []struct {
+		field    string
+		min, max int
+		want     []int
+		wantErr  bool
+	}{
+		{field: "*", min: 0, max: 3, want: []int{0, 1, 2, 3}},
+		{field: "*/15", min: 0, max: 59, want: []int{0, 15, 30, 45}},
+		{field: "10/20", min: 0, max: 59, want: []int{10, 30, 50}},
+		{field: "1-5", min: 0, max: 6, want: []int{1, 2, 3, 4, 5}},
+		{field: "1-10/3", min: 1, max: 31, want: []int{1, 4, 7, 10}},
+		{field: "0,30", min: 0, max: 59, want: []int{0, 30}},
+		{field: "1-2,5", min: 0, max: 6, want: []int{1, 2, 5}},
+		{field: "60", min: 0, max: 59, wantErr: true},
+		{field: "5-1", min: 0, max: 59, wantErr: true},
+		{field: "*/0", min: 0, max: 59, wantErr: true},
+		{field: "a", min: 0, max: 59, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseCronField(tt.field, tt.min, tt.max)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseCronField(%q) succeeded, want error", tt.field)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseCronField(%q) failed: %v", tt.field, err)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("parseCronField(%q) = %v, want %v", tt.field, got, tt.want)
+			continue
+		}
+		for _, v := range tt.want {
+			if !got[v] {
+				t.Errorf("parseCronField(%q) = %v, want %v", tt.field, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "* * * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8"} {
+		if _, err := parseCron(expr); err == nil {
+			t.Errorf("parseCron(%q) succeeded, want error", expr)
+		}
+	}
+}
+
+func TestNextCronRun(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data not available: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		expr     string
+		timezone string
+		after    time.Time
+		want     time.Time
+	}{
+		{
+			name:  "every minute rounds up to the next minute",
+			expr:  "* * * * *",
+			after: time.Date(2026, 10, 19, 10, 7, 30, 0, time.UTC),
+			want:  time.Date(2026, 10, 19, 10, 8, 0, 0, time.UTC),
+		},
+		{
+			name:  "step",
+			expr:  "*/15 * * * *",
+			after: time.Date(2026, 10, 19, 10, 7, 0, 0, time.UTC),
+			want:  time.Date(2026, 10, 19, 10, 15, 0, 0, time.UTC),
+		},
+		{
+			name:  "exact time is not repeated",
+			expr:  "0 9 * * *",
+			after: time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC),
+			want:  time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "weekday range skips the weekend",
+			expr:  "0 9 * * 1-5",
+			after: time.Date(2026, 10, 23, 10, 0, 0, 0, time.UTC), // Friday
+			want:  time.Date(2026, 10, 26, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "sunday as 7",
+			expr:  "0 12 * * 7",
+			after: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2026, 10, 25, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "day of month",
+			expr:  "30 8 1 * *",
+			after: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2026, 11, 1, 8, 30, 0, 0, time.UTC),
+		},
+		{
+			name:  "day of month or weekday when both are restricted",
+			expr:  "0 0 13 * 5",
+			after: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC), // Friday before the 13th
+		},
+		{
+			name:  "leap day",
+			expr:  "0 0 29 2 *",
+			after: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "evaluated in the given timezone",
+			expr:     "0 9 * * *",
+			timezone: "America/New_York",
+			after:    time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+			want:     time.Date(2026, 10, 19, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "time skipped when clocks spring forward",
+			expr:     "30 2 * * *",
+			timezone: "America/New_York",
+			after:    time.Date(2026, 3, 7, 12, 0, 0, 0, newYork),
+			want:     time.Date(2026, 3, 9, 2, 30, 0, 0, newYork),
+		},
+		{
+			name:     "hour after clocks spring forward",
+			expr:     "30 3 * * *",
+			timezone: "America/New_York",
+			after:    time.Date(2026, 3, 7, 12, 0, 0, 0, newYork),
+			want:     time.Date(2026, 3, 8, 3, 30, 0, 0, newYork),
+		},
+		{
+			name:     "repeated time runs once when clocks fall back",
+			expr:     "30 1 * * *",
+			timezone: "America/New_York",
+			after:    time.Date(2026, 10, 31, 12, 0, 0, 0, newYork),
+			want:     time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC), // 01:30 EDT
+		},
+		{
+			name:     "repeated time is not run again after clocks fall back",
+			expr:     "30 1 * * *",
+			timezone: "America/New_York",
+			after:    time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC), // 01:30 EDT
+			want:     time.Date(2026, 11, 2, 6, 30, 0, 0, time.UTC), // 01:30 EST the next day
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nextCronRun(tt.expr, tt.timezone, tt.after)
+			if err != nil {
+				t.Fatalf("nextCronRun(%q) failed: %v", tt.expr, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("nextCronRun(%q, %q, %v) = %v, want %v", tt.expr, tt.timezone, tt.after, got, tt.want.UTC())
+			}
+		})
+	}
+}
+
+func TestNextCronRunNeverMatches(t *testing.T) {
+	if _, err := nextCronRun("0 0 31 2 *", "UTC", time.Now()); err == nil {
+		t.Error("nextCronRun for February 31st succeeded, want error")
+	}
+}
+
+func TestClaimDueSchedules(t *testing.T) {
+	t.Setenv("MMCTL_MCP_DATA_DIR", t.TempDir())
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Minute).Format(time.RFC3339)
+	schedules := []ScheduledPost{
+		{ID: "due", NextRun: past},
+		{ID: "future", NextRun: now.Add(time.Hour).Format(time.RFC3339)},
+		{ID: "sending", NextRun: past, Status: scheduleRunning, ClaimedAt: now.Add(-time.Minute).Format(time.RFC3339)},
+		{ID: "crashed", NextRun: past, Status: scheduleRunning, ClaimedAt: now.Add(-time.Hour).Format(time.RFC3339)},
+		{ID: "unclaimed", NextRun: past, Status: scheduleRunning},
+	}
+	if err := saveDataFile("schedules.json", schedules); err != nil {
+		t.Fatal(err)
+	}
+
+	due, err := claimDueSchedules(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(due) != 1 || due[0].ID != "due" {
+		t.Errorf("claimed %v, want only due", due)
+	}
+
+	saved, err := loadSchedules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"due":       scheduleRunning,
+		"future":    "",
+		"sending":   scheduleRunning,
+		"crashed":   scheduleFailed,
+		"unclaimed": scheduleFailed,
+	}
+	for _, s := range saved {
+		if s.Status != want[s.ID] {
+			t.Errorf("%s has status %q, want %q", s.ID, s.Status, want[s.ID])
+		}
+	}
+	if saved[0].ClaimedAt != now.Format(time.RFC3339) {
+		t.Errorf("due claimed at %q, want %q", saved[0].ClaimedAt, now.Format(time.RFC3339))
+	}
+}
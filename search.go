@@ -0,0 +1,259 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+// PostSearchArgs represents arguments for searching posts
+type PostSearchArgs struct {
+	Terms                  string   `json:"terms" jsonschema:"description=Search terms; may also contain Mattermost modifiers such as from:, in:, after:, before:, on: and \"quoted phrases\""`
+	From                   []string `json:"from" jsonschema:"description=Only match posts by these usernames"`
+	In                     []string `json:"in" jsonschema:"description=Only match posts in these channels (channel names)"`
+	Hashtags               []string `json:"hashtags" jsonschema:"description=Only match posts with these hashtags (with or without #)"`
+	After                  string   `json:"after" jsonschema:"description=Only match posts after this date (YYYY-MM-DD)"`
+	Before                 string   `json:"before" jsonschema:"description=Only match posts before this date (YYYY-MM-DD)"`
+	On                     string   `json:"on" jsonschema:"description=Only match posts on this date (YYYY-MM-DD)"`
+	Team                   string   `json:"team" jsonschema:"description=Only search this team (name or ID); all teams are searched by default"`
+	AsUser                 string   `json:"asUser" jsonschema:"required,description=Search as this user (username or ID); the server only searches the channels the searching user is a member of"`
+	OrSearch               bool     `json:"orSearch" jsonschema:"description=Match posts containing any of the terms instead of all of them"`
+	IncludeDeletedChannels bool     `json:"includeDeletedChannels" jsonschema:"description=Also search archived channels"`
+	Limit                  int      `json:"limit" jsonschema:"description=Maximum number of results (default 100)"`
+}
+
+// PostSearchResult is a post matching a search
+type PostSearchResult struct {
+	PostID    string `json:"postId"`
+	RootID    string `json:"rootId,omitempty"`
+	Team      string `json:"team,omitempty"`
+	Channel   string `json:"channel"`
+	ChannelID string `json:"channelId"`
+	Author    string `json:"author"`
+	CreatedAt string `json:"createdAt"`
+	Snippet   string `json:"snippet"`
+}
+
+// PostSearchResponse holds the posts matching a search
+type PostSearchResponse struct {
+	Results []PostSearchResult `json:"results"`
+}
+
+// searchTerms builds the Mattermost search string from the structured arguments
+func searchTerms(args PostSearchArgs) string {
+	parts := []string{}
+	if args.Terms != "" {
+		parts = append(parts, args.Terms)
+	}
+	for _, u := range args.From {
+		parts = append(parts, "from:"+strings.TrimPrefix(u, "@"))
+	}
+	for _, c := range args.In {
+		// Accept team:channel references, search only knows channel names
+		if _, name, ok := strings.Cut(c, ":"); ok {
+			c = name
+		}
+		parts = append(parts, "in:"+strings.TrimPrefix(c, "~"))
+	}
+	for _, h := range args.Hashtags {
+		parts = append(parts, "#"+strings.TrimPrefix(h, "#"))
+	}
+	if args.After != "" {
+		parts = append(parts, "after:"+args.After)
+	}
+	if args.Before != "" {
+		parts = append(parts, "before:"+args.Before)
+	}
+	if args.On != "" {
+		parts = append(parts, "on:"+args.On)
+	}
+	return strings.Join(parts, " ")
+}
+
+// searchSnippet returns the part of message around the first of the given
+// words it contains, shortened to about 200 characters
+func searchSnippet(message string, words []string) string {
+	const width = 200
+	runes := []rune(strings.Join(strings.Fields(message), " "))
+	if len(runes) <= width {
+		return string(runes)
+	}
+
+	// Lowercase rune by rune so that match positions stay valid in runes
+	start := 0
+	lower := lowerRunes(string(runes))
+	for _, w := range words {
+		w = strings.Trim(w, "\"*#")
+		if w == "" || strings.Contains(w, ":") {
+			continue
+		}
+		if i := runesIndex(lower, lowerRunes(w)); i >= 0 {
+			start = i - width/4
+			break
+		}
+	}
+
+	if start < 0 {
+		start = 0
+	}
+	if start > len(runes)-width {
+		start = len(runes) - width
+	}
+
+	snippet := string(runes[start : start+width])
+	if start > 0 {
+		snippet = "..." + snippet
+	}
+	if start+width < len(runes) {
+		snippet += "..."
+	}
+	return snippet
+}
+
+// lowerRunes returns the runes of s, each mapped to lower case
+func lowerRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
+
+// runesIndex returns the index of the first occurrence of sub in s, or -1
+func runesIndex(s, sub []rune) int {
+	for i := 0; i+len(sub) <= len(s); i++ {
+		if string(s[i:i+len(sub)]) == string(sub) {
+			return i
+		}
+	}
+	return -1
+}
+
+// RegisterSearchTools registers all search related tools
+func RegisterSearchTools(server *mcp_golang.Server) error {
+	// Register post search tool
+	err := server.RegisterTool("post_search", "Search posts across channels and teams by terms, author, channel, date and hashtags, as a user covering the channels they are a member of", func(args PostSearchArgs) (*mcp_golang.ToolResponse, error) {
+		terms := searchTerms(args)
+		if terms == "" {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: at least one search criterion is required")), nil
+		}
+
+		// Local mode searches have no user and so find nothing
+		if args.AsUser == "" {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: asUser is required, as the server only searches channels the searching user is a member of; use post_export to read the history of a channel instead")), nil
+		}
+
+		limit := args.Limit
+		if limit <= 0 {
+			limit = 100
+		}
+
+		var teams []Team
+		if args.Team != "" {
+			team, err := getTeam(args.Team)
+			if err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+			teams = []Team{*team}
+		} else {
+			var err error
+			if teams, err = listTeams(); err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+		}
+
+		userID, err := resolveImpersonatedUser(args.AsUser)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		// Direct and group messages match in every team, so collect unique posts
+		posts := map[string]Post{}
+		err = withUserAPI(userID, func(api *apiClient) error {
+			for _, team := range teams {
+				body := map[string]interface{}{
+					"terms":                    terms,
+					"is_or_search":             args.OrSearch,
+					"include_deleted_channels": args.IncludeDeletedChannels,
+					"time_zone_offset":         0,
+					"page":                     0,
+					"per_page":                 limit,
+				}
+				var list PostList
+				if err := api.call("POST", "/teams/"+team.ID+"/posts/search", body, &list); err != nil {
+					return fmt.Errorf("error searching team %s: %w", team.Name, err)
+				}
+				for _, id := range list.Order {
+					posts[id] = list.Posts[id]
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		sorted := make([]Post, 0, len(posts))
+		userIDs := []string{}
+		seenUsers := map[string]bool{}
+		for _, p := range posts {
+			sorted = append(sorted, p)
+			if !seenUsers[p.UserID] {
+				seenUsers[p.UserID] = true
+				userIDs = append(userIDs, p.UserID)
+			}
+		}
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].CreateAt > sorted[j].CreateAt })
+		if len(sorted) > limit {
+			sorted = sorted[:limit]
+		}
+
+		users, err := getUsersByIDs(userIDs)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		teamNames := map[string]string{}
+		for _, team := range teams {
+			teamNames[team.ID] = team.Name
+		}
+
+		channels := map[string]*Channel{}
+		words := append(strings.Fields(args.Terms), args.Hashtags...)
+		response := PostSearchResponse{Results: []PostSearchResult{}}
+		for _, p := range sorted {
+			channel, ok := channels[p.ChannelID]
+			if !ok {
+				if channel, err = getChannel(p.ChannelID); err != nil {
+					return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+				}
+				channels[p.ChannelID] = channel
+			}
+
+			author := p.UserID
+			if u, ok := users[p.UserID]; ok {
+				author = u.Username
+			}
+
+			response.Results = append(response.Results, PostSearchResult{
+				PostID:    p.ID,
+				RootID:    p.RootID,
+				Team:      teamNames[channel.TeamID],
+				Channel:   channel.Name,
+				ChannelID: channel.ID,
+				Author:    author,
+				CreatedAt: formatMillis(p.CreateAt),
+				Snippet:   searchSnippet(p.Message, words),
+			})
+		}
+		return newJSONToolResponse(response), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register post_search tool: %v", err)
+	}
+
+	return nil
+}
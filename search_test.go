@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSearchSnippet(t *testing.T) {
+	filler := strings.Repeat("filler ", 60)
+
+	tests := []struct {
+		name     string
+		message  string
+		words    []string
+		contains string
+		prefix   bool
+	}{
+		{
+			name:     "short message is returned whole",
+			message:  "the  password\nis secret",
+			words:    []string{"secret"},
+			contains: "the password is secret",
+		},
+		{
+			name:     "match near the start",
+			message:  "secret " + filler,
+			words:    []string{"secret"},
+			contains: "secret",
+		},
+		{
+			name:     "match far into the message",
+			message:  filler + "the secret token " + filler,
+			words:    []string{"Secret"},
+			contains: "the secret token",
+			prefix:   true,
+		},
+		{
+			name:     "lowercase longer in bytes than the original",
+			message:  strings.Repeat("Ⱥ", 150) + " secret " + filler,
+			words:    []string{"secret"},
+			contains: "secret",
+			prefix:   true,
+		},
+		{
+			name:     "multibyte match",
+			message:  filler + "ȺBC key " + filler,
+			words:    []string{"ⱥbc"},
+			contains: "ȺBC key",
+			prefix:   true,
+		},
+		{
+			name:     "modifiers and quotes are ignored",
+			message:  filler + "the secret token " + filler,
+			words:    []string{"from:alice", "\"secret"},
+			contains: "the secret token",
+			prefix:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := searchSnippet(tt.message, tt.words)
+			if !strings.Contains(got, tt.contains) {
+				t.Errorf("searchSnippet() = %q, want it to contain %q", got, tt.contains)
+			}
+			if tt.prefix != strings.HasPrefix(got, "...") {
+				t.Errorf("searchSnippet() = %q, want prefix ellipsis %v", got, tt.prefix)
+			}
+			if n := utf8.RuneCountInString(strings.Trim(got, ".")); n > 200 {
+				t.Errorf("searchSnippet() returned %d characters, want at most 200", n)
+			}
+		})
+	}
+}
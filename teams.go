@@ -2,10 +2,59 @@ package main
 
 import (
 	"fmt"
+	"strings"
 
 	mcp_golang "github.com/metoro-io/mcp-golang"
 )
 
+// Team represents the fields of a Mattermost team used by the tools
+type Team struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	DisplayName      string `json:"display_name"`
+	Description      string `json:"description"`
+	Email            string `json:"email"`
+	Type             string `json:"type"`
+	AllowedDomains   string `json:"allowed_domains"`
+	InviteID         string `json:"invite_id"`
+	AllowOpenInvite  bool   `json:"allow_open_invite"`
+	GroupConstrained *bool  `json:"group_constrained"`
+	DeleteAt         int64  `json:"delete_at"`
+}
+
+// TeamMember represents the membership of a user in a team
+type TeamMember struct {
+	TeamID        string `json:"team_id"`
+	UserID        string `json:"user_id"`
+	Roles         string `json:"roles"`
+	SchemeAdmin   bool   `json:"scheme_admin"`
+	SchemeUser    bool   `json:"scheme_user"`
+	SchemeGuest   bool   `json:"scheme_guest"`
+	ExplicitRoles string `json:"explicit_roles"`
+	DeleteAt      int64  `json:"delete_at"`
+}
+
+// getTeam looks up a single team by name or ID
+func getTeam(term string) (*Team, error) {
+	var team Team
+	if err := executeLocalAPI("GET", "/teams/name/"+term, nil, &team); err == nil {
+		return &team, nil
+	}
+	if err := executeLocalAPI("GET", "/teams/"+term, nil, &team); err != nil {
+		return nil, fmt.Errorf("team %s not found: %w", term, err)
+	}
+	return &team, nil
+}
+
+// listTeams fetches all teams, including archived ones
+func listTeams() ([]Team, error) {
+	var teams []Team
+	if err := executeMMCTLJSON(&teams, "team", "list"); err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
 // TeamCreateArgs represents arguments for team create command
 type TeamCreateArgs struct {
 	Name        string `json:"name" jsonschema:"required,description=Team name (lowercase, no spaces)"`
@@ -21,9 +70,43 @@ type TeamSearchArgs struct {
 
 // TeamModifyArgs represents arguments for team modify command
 type TeamModifyArgs struct {
-	Team    string `json:"team" jsonschema:"required,description=Team to modify (name or ID)"`
-	Private bool   `json:"private" jsonschema:"description=Make the team private"`
-	Public  bool   `json:"public" jsonschema:"description=Make the team public"`
+	Team               string  `json:"team" jsonschema:"required,description=Team to modify (name or ID)"`
+	Privacy            string  `json:"privacy" jsonschema:"enum=public,enum=private,description=Team privacy: public or private (invite only)"`
+	Description        *string `json:"description" jsonschema:"description=New team description"`
+	AllowedDomains     *string `json:"allowedDomains" jsonschema:"description=Comma separated email domains allowed to join the team (empty to allow any)"`
+	AllowOpenInvite    *bool   `json:"allowOpenInvite" jsonschema:"description=Whether any user on the server can join the team"`
+	RegenerateInviteID bool    `json:"regenerateInviteId" jsonschema:"description=Regenerate the team invite ID, invalidating existing invite links"`
+}
+
+// TeamSettings represents the editable settings of a team
+type TeamSettings struct {
+	Privacy         string `json:"privacy"`
+	Description     string `json:"description"`
+	AllowedDomains  string `json:"allowedDomains"`
+	AllowOpenInvite bool   `json:"allowOpenInvite"`
+	InviteID        string `json:"inviteId"`
+}
+
+// TeamModifyResult reports the team settings before and after a modification
+type TeamModifyResult struct {
+	Team     string       `json:"team"`
+	Previous TeamSettings `json:"previous"`
+	Updated  TeamSettings `json:"updated"`
+}
+
+// newTeamSettings extracts the editable settings of a team
+func newTeamSettings(t *Team) TeamSettings {
+	privacy := "public"
+	if t.Type == "I" {
+		privacy = "private"
+	}
+	return TeamSettings{
+		Privacy:         privacy,
+		Description:     t.Description,
+		AllowedDomains:  t.AllowedDomains,
+		AllowOpenInvite: t.AllowOpenInvite,
+		InviteID:        t.InviteID,
+	}
 }
 
 // TeamRenameArgs represents arguments for team rename command
@@ -32,6 +115,333 @@ type TeamRenameArgs struct {
 	DisplayName string `json:"displayName" jsonschema:"required,description=New display name"`
 }
 
+// TeamMembersListArgs represents arguments for listing team members
+type TeamMembersListArgs struct {
+	Team    string `json:"team" jsonschema:"required,description=Team name or ID"`
+	Page    int    `json:"page" jsonschema:"description=Page number"`
+	PerPage int    `json:"perPage" jsonschema:"description=Number of members per page (default 60)"`
+}
+
+// TeamMembersRemoveArgs represents arguments for team users remove command
+type TeamMembersRemoveArgs struct {
+	Team  string   `json:"team" jsonschema:"required,description=Team name or ID"`
+	Users []string `json:"users" jsonschema:"required,description=Users to remove (usernames, emails, or IDs)"`
+}
+
+// TeamMembersSyncArgs represents arguments for syncing team members with a desired list
+type TeamMembersSyncArgs struct {
+	Team       string   `json:"team" jsonschema:"required,description=Team name or ID"`
+	Users      []string `json:"users" jsonschema:"required,description=Desired team members (usernames, emails, or IDs)"`
+	RemoveBots bool     `json:"removeBots" jsonschema:"description=Also remove bot accounts missing from the desired list; they are kept by default"`
+	DryRun     bool     `json:"dryRun" jsonschema:"description=Only compute the members to add and remove"`
+}
+
+// TeamArchiveArgs represents arguments for team archive command
+type TeamArchiveArgs struct {
+	Teams []string `json:"teams" jsonschema:"required,description=Teams to archive (name or ID)"`
+}
+
+// TeamRestoreArgs represents arguments for team restore command
+type TeamRestoreArgs struct {
+	Teams []string `json:"teams" jsonschema:"required,description=Archived teams to restore (name or ID)"`
+}
+
+// TeamDeleteArgs represents arguments for team delete command
+type TeamDeleteArgs struct {
+	Team    string `json:"team" jsonschema:"required,description=Team to permanently delete (name or ID)"`
+	Confirm string `json:"confirm" jsonschema:"description=Must be the team name to confirm deletion; without it only the impact is reported"`
+}
+
+// TeamDeleteImpact reports what a permanent team deletion affects
+type TeamDeleteImpact struct {
+	Team     string `json:"team"`
+	Channels int    `json:"channels"`
+	Posts    int64  `json:"posts"`
+	Members  int64  `json:"members"`
+	Deleted  bool   `json:"deleted"`
+	Message  string `json:"message"`
+}
+
+// TeamCloneArgs represents arguments for cloning a team from a template
+type TeamCloneArgs struct {
+	Template          string `json:"template" jsonschema:"required,description=Template team to copy (name or ID)"`
+	Name              string `json:"name" jsonschema:"required,description=Name of the new team (lowercase, no spaces)"`
+	DisplayName       string `json:"displayName" jsonschema:"required,description=Display name of the new team"`
+	Email             string `json:"email" jsonschema:"description=Administrator email address"`
+	CategoriesUser    string `json:"categoriesUser" jsonschema:"description=User whose custom sidebar categories in the template are recreated in the new team (username, email, or ID); they must be a member of the new team"`
+	AddCategoriesUser bool   `json:"addCategoriesUser" jsonschema:"description=Add the categories user to the new team so their categories can be created"`
+	IncludeWebhooks   bool   `json:"includeWebhooks" jsonschema:"description=Also copy the incoming webhooks of the template channels"`
+}
+
+// TeamCloneResult reports what was created by a team clone
+type TeamCloneResult struct {
+	Team         string   `json:"team"`
+	TeamID       string   `json:"teamId"`
+	Channels     []string `json:"channels"`
+	Groups       []string `json:"groups"`
+	Categories   []string `json:"categories"`
+	AddedMembers []string `json:"addedMembers,omitempty"`
+	Webhooks     []string `json:"webhooks"`
+	Errors       []string `json:"errors,omitempty"`
+}
+
+// Group represents the fields of a Mattermost group used by the tools
+type Group struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+}
+
+// linkedGroups fetches the groups linked to a team or channel.
+// syncablePath is the API path of the team or channel.
+func linkedGroups(syncablePath string) ([]Group, error) {
+	var resp struct {
+		Groups []Group `json:"groups"`
+	}
+	if err := executeLocalAPI("GET", syncablePath+"/groups", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Groups, nil
+}
+
+// copyGroupLinks links the groups of one team or channel to another and
+// returns the names of the linked groups
+func copyGroupLinks(syncableType, fromPath, toID string) ([]string, error) {
+	groups, err := linkedGroups(fromPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, g := range groups {
+		path := fmt.Sprintf("/groups/%s/%ss/%s/link", g.ID, syncableType, toID)
+		if err := executeLocalAPI("POST", path, map[string]bool{"auto_add": true}, nil); err != nil {
+			return names, err
+		}
+		names = append(names, g.DisplayName)
+	}
+	return names, nil
+}
+
+// cloneTeam creates a new team with the layout and settings of a template team
+func cloneTeam(args TeamCloneArgs) (*TeamCloneResult, error) {
+	template, err := getTeam(args.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	cmdArgs := []string{"team", "create", "--name", args.Name, "--display-name", args.DisplayName}
+	if args.Email != "" {
+		cmdArgs = append(cmdArgs, "--email", args.Email)
+	}
+	if template.Type == "I" {
+		cmdArgs = append(cmdArgs, "--private")
+	}
+	if _, err := executeMMCTL(cmdArgs...); err != nil {
+		return nil, err
+	}
+
+	team, err := getTeam(args.Name)
+	if err != nil {
+		return nil, err
+	}
+	result := &TeamCloneResult{Team: team.Name, TeamID: team.ID, Channels: []string{}, Groups: []string{}, Categories: []string{}, Webhooks: []string{}}
+
+	patch := map[string]interface{}{
+		"description":       template.Description,
+		"allowed_domains":   template.AllowedDomains,
+		"allow_open_invite": template.AllowOpenInvite,
+	}
+	if err := executeLocalAPI("PUT", "/teams/"+team.ID+"/patch", patch, nil); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("team settings: %v", err))
+	}
+
+	groups, err := copyGroupLinks("team", "/teams/"+template.ID, team.ID)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("team groups: %v", err))
+	}
+	result.Groups = append(result.Groups, groups...)
+	if template.GroupConstrained != nil && *template.GroupConstrained {
+		if err := executeLocalAPI("PUT", "/teams/"+team.ID+"/patch", map[string]bool{"group_constrained": true}, nil); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("team group constraint: %v", err))
+		}
+	}
+
+	channels, err := listChannels(template.ID)
+	if err != nil {
+		return result, err
+	}
+
+	// Map template channel IDs to the IDs of their copies
+	channelIDs := map[string]string{}
+	for _, ch := range channels {
+		if ch.DeleteAt != 0 {
+			continue
+		}
+
+		// Town Square and Off-Topic are created with the team
+		if ch.Name != "town-square" && ch.Name != "off-topic" {
+			cmdArgs := []string{"channel", "create", "--team", team.ID, "--name", ch.Name, "--display-name", ch.DisplayName}
+			if ch.Type == "P" {
+				cmdArgs = append(cmdArgs, "--private")
+			}
+			if _, err := executeMMCTL(cmdArgs...); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("channel %s: %v", ch.Name, err))
+				continue
+			}
+		}
+
+		created, err := getChannel(team.Name + ":" + ch.Name)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("channel %s: %v", ch.Name, err))
+			continue
+		}
+		channelIDs[ch.ID] = created.ID
+		result.Channels = append(result.Channels, ch.Name)
+
+		patch := map[string]string{"header": ch.Header, "purpose": ch.Purpose}
+		if err := executeLocalAPI("PUT", "/channels/"+created.ID+"/patch", patch, nil); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("channel %s settings: %v", ch.Name, err))
+		}
+
+		groups, err := copyGroupLinks("channel", "/channels/"+ch.ID, created.ID)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("channel %s groups: %v", ch.Name, err))
+		}
+		for _, g := range groups {
+			result.Groups = append(result.Groups, ch.Name+":"+g)
+		}
+		if ch.GroupConstrained != nil && *ch.GroupConstrained {
+			if err := executeLocalAPI("PUT", "/channels/"+created.ID+"/patch", map[string]bool{"group_constrained": true}, nil); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("channel %s group constraint: %v", ch.Name, err))
+			}
+		}
+	}
+
+	if args.CategoriesUser != "" {
+		if err := cloneSidebarCategories(args.CategoriesUser, args.AddCategoriesUser, template, team, channelIDs, result); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("categories: %v", err))
+		}
+	}
+
+	if args.IncludeWebhooks {
+		var hooks []IncomingWebhook
+		for page := 0; ; page++ {
+			var batch []IncomingWebhook
+			if err := executeLocalAPI("GET", fmt.Sprintf("/hooks/incoming?page=%d&per_page=200&team_id=%s", page, template.ID), nil, &batch); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("webhooks: %v", err))
+				break
+			}
+			if len(batch) == 0 {
+				break
+			}
+			hooks = append(hooks, batch...)
+		}
+
+		for _, hook := range hooks {
+			channelID, ok := channelIDs[hook.ChannelID]
+			if !ok {
+				continue
+			}
+
+			cmdArgs := []string{"webhook", "create-incoming", "--channel", channelID, "--user", hook.UserID}
+			if hook.DisplayName != "" {
+				cmdArgs = append(cmdArgs, "--display-name", hook.DisplayName)
+			}
+			if hook.Description != "" {
+				cmdArgs = append(cmdArgs, "--description", hook.Description)
+			}
+			if hook.ChannelLocked {
+				cmdArgs = append(cmdArgs, "--lock-to-channel")
+			}
+			if hook.IconURL != "" {
+				cmdArgs = append(cmdArgs, "--icon", hook.IconURL)
+			}
+			if _, err := executeMMCTL(cmdArgs...); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("webhook %s: %v", hook.DisplayName, err))
+				continue
+			}
+			result.Webhooks = append(result.Webhooks, hook.DisplayName)
+		}
+	}
+
+	return result, nil
+}
+
+// cloneSidebarCategories recreates a user's custom sidebar categories from
+// the template team in the new team, mapping channels to their copies. The
+// user is only added to the new team when addUser is set.
+func cloneSidebarCategories(term string, addUser bool, template, team *Team, channelIDs map[string]string, result *TeamCloneResult) error {
+	user, err := getUser(term)
+	if err != nil {
+		return err
+	}
+
+	if err := executeLocalAPI("GET", "/teams/"+team.ID+"/members/"+user.ID, nil, nil); err != nil {
+		if !addUser {
+			return fmt.Errorf("%s is not a member of the new team; set addCategoriesUser to add them", user.Username)
+		}
+		if _, err := executeMMCTL("team", "users", "add", team.ID, user.ID); err != nil {
+			return err
+		}
+		result.AddedMembers = append(result.AddedMembers, user.Username)
+	}
+
+	var categories SidebarCategories
+	if err := executeLocalAPI("GET", "/users/"+user.ID+"/teams/"+template.ID+"/channels/categories", nil, &categories); err != nil {
+		return err
+	}
+
+	for _, category := range categories.Categories {
+		if category.Type != "custom" {
+			continue
+		}
+
+		copied := SidebarCategory{
+			UserID:      user.ID,
+			TeamID:      team.ID,
+			DisplayName: category.DisplayName,
+			Sorting:     category.Sorting,
+			Muted:       category.Muted,
+			Collapsed:   category.Collapsed,
+			ChannelIDs:  []string{},
+		}
+		for _, id := range category.ChannelIDs {
+			if newID, ok := channelIDs[id]; ok {
+				copied.ChannelIDs = append(copied.ChannelIDs, newID)
+			}
+		}
+
+		if err := executeLocalAPI("POST", "/users/"+user.ID+"/teams/"+team.ID+"/channels/categories", copied, nil); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("category %s: %v", category.DisplayName, err))
+			continue
+		}
+		result.Categories = append(result.Categories, category.DisplayName)
+	}
+	return nil
+}
+
+// TeamMemberInfo summarizes a team member and their roles
+type TeamMemberInfo struct {
+	UserID   string `json:"userId"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Roles    string `json:"roles"`
+	Admin    bool   `json:"admin"`
+	Guest    bool   `json:"guest"`
+}
+
+// TeamSyncResult reports the membership changes computed by a sync
+type TeamSyncResult struct {
+	Team     string   `json:"team"`
+	DryRun   bool     `json:"dryRun"`
+	Add      []string `json:"add"`
+	Remove   []string `json:"remove"`
+	KeptBots []string `json:"keptBots,omitempty"`
+	Errors   []string `json:"errors,omitempty"`
+	Applied  bool     `json:"applied"`
+}
+
 // RegisterTeamTools registers all team related tools
 func RegisterTeamTools(server *mcp_golang.Server) error {
 	// Register team create tool
@@ -78,25 +488,51 @@ func RegisterTeamTools(server *mcp_golang.Server) error {
 	}
 
 	// Register team modify tool
-	err = server.RegisterTool("team_modify", "Modify a team", func(args TeamModifyArgs) (*mcp_golang.ToolResponse, error) {
-		cmdArgs := []string{"team", "modify", args.Team}
-		
-		if args.Private {
-			cmdArgs = append(cmdArgs, "--private")
-		}
-		
-		if args.Public {
-			cmdArgs = append(cmdArgs, "--public")
+	err = server.RegisterTool("team_modify", "Modify team privacy and settings, returning the previous and updated values", func(args TeamModifyArgs) (*mcp_golang.ToolResponse, error) {
+		if args.Privacy != "" && args.Privacy != "public" && args.Privacy != "private" {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: privacy must be public or private")), nil
 		}
-		
-		output, err := executeMMCTL(cmdArgs...)
+
+		team, err := getTeam(args.Team)
 		if err != nil {
 			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
 		}
-		if output == "" {
-			output = "Team modified successfully"
+		result := TeamModifyResult{Team: team.Name, Previous: newTeamSettings(team)}
+
+		if args.Privacy != "" {
+			if _, err := executeMMCTL("team", "modify", team.ID, "--"+args.Privacy); err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
 		}
-		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(output)), nil
+
+		patch := map[string]interface{}{}
+		if args.Description != nil {
+			patch["description"] = *args.Description
+		}
+		if args.AllowedDomains != nil {
+			patch["allowed_domains"] = *args.AllowedDomains
+		}
+		if args.AllowOpenInvite != nil {
+			patch["allow_open_invite"] = *args.AllowOpenInvite
+		}
+		if len(patch) > 0 {
+			if err := executeLocalAPI("PUT", "/teams/"+team.ID+"/patch", patch, nil); err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+		}
+
+		if args.RegenerateInviteID {
+			if err := executeLocalAPI("POST", "/teams/"+team.ID+"/regenerate_invite_id", nil, nil); err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+		}
+
+		updated, err := getTeam(team.ID)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		result.Updated = newTeamSettings(updated)
+		return newJSONToolResponse(result), nil
 	})
 	if err != nil {
 		return fmt.Errorf("failed to register team_modify tool: %v", err)
@@ -119,5 +555,216 @@ func RegisterTeamTools(server *mcp_golang.Server) error {
 		return fmt.Errorf("failed to register team_rename tool: %v", err)
 	}
 
+	// Register team members list tool
+	err = server.RegisterTool("team_members_list", "List the members of a team with their roles", func(args TeamMembersListArgs) (*mcp_golang.ToolResponse, error) {
+		team, err := getTeam(args.Team)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		perPage := args.PerPage
+		if perPage <= 0 {
+			perPage = 60
+		}
+
+		var members []TeamMember
+		if err := executeLocalAPI("GET", fmt.Sprintf("/teams/%s/members?page=%d&per_page=%d", team.ID, args.Page, perPage), nil, &members); err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		ids := []string{}
+		for _, m := range members {
+			ids = append(ids, m.UserID)
+		}
+		users, err := getUsersByIDs(ids)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		infos := []TeamMemberInfo{}
+		for _, m := range members {
+			infos = append(infos, TeamMemberInfo{
+				UserID:   m.UserID,
+				Username: users[m.UserID].Username,
+				Email:    users[m.UserID].Email,
+				Roles:    m.Roles,
+				Admin:    m.SchemeAdmin,
+				Guest:    m.SchemeGuest,
+			})
+		}
+		return newJSONToolResponse(infos), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register team_members_list tool: %v", err)
+	}
+
+	// Register team members remove tool
+	err = server.RegisterTool("team_members_remove", "Remove users from a team", func(args TeamMembersRemoveArgs) (*mcp_golang.ToolResponse, error) {
+		cmdArgs := []string{"team", "users", "remove", args.Team}
+		cmdArgs = append(cmdArgs, args.Users...)
+
+		output, err := executeMMCTL(cmdArgs...)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		if output == "" {
+			output = "Users removed from team successfully"
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(output)), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register team_members_remove tool: %v", err)
+	}
+
+	// Register team members sync tool
+	err = server.RegisterTool("team_members_sync", "Reconcile team membership with a desired list of users, adding and removing members. Bots missing from the list are kept and reported unless removeBots is set", func(args TeamMembersSyncArgs) (*mcp_golang.ToolResponse, error) {
+		members, err := listUsers(args.Team)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		desired := map[string]bool{}
+		for _, u := range args.Users {
+			desired[strings.ToLower(u)] = true
+		}
+
+		result := TeamSyncResult{Team: args.Team, DryRun: args.DryRun, Add: []string{}, Remove: []string{}}
+		present := map[string]bool{}
+		for _, m := range members {
+			keys := []string{m.ID, strings.ToLower(m.Username), strings.ToLower(m.Email)}
+			wanted := false
+			for _, k := range keys {
+				present[k] = true
+				wanted = wanted || desired[k]
+			}
+			if wanted {
+				continue
+			}
+			if m.IsBot && !args.RemoveBots {
+				result.KeptBots = append(result.KeptBots, m.Username)
+				continue
+			}
+			result.Remove = append(result.Remove, m.Username)
+		}
+
+		for _, u := range args.Users {
+			if !present[strings.ToLower(u)] {
+				result.Add = append(result.Add, u)
+			}
+		}
+
+		if !args.DryRun {
+			if len(result.Add) > 0 {
+				cmdArgs := append([]string{"team", "users", "add", args.Team}, result.Add...)
+				if _, err := executeMMCTL(cmdArgs...); err != nil {
+					result.Errors = append(result.Errors, fmt.Sprintf("add: %v", err))
+				}
+			}
+			if len(result.Remove) > 0 {
+				cmdArgs := append([]string{"team", "users", "remove", args.Team}, result.Remove...)
+				if _, err := executeMMCTL(cmdArgs...); err != nil {
+					result.Errors = append(result.Errors, fmt.Sprintf("remove: %v", err))
+				}
+			}
+			result.Applied = len(result.Errors) == 0
+		}
+
+		return newJSONToolResponse(result), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register team_members_sync tool: %v", err)
+	}
+
+	// Register team archive tool
+	err = server.RegisterTool("team_archive", "Archive teams (soft delete)", func(args TeamArchiveArgs) (*mcp_golang.ToolResponse, error) {
+		cmdArgs := []string{"team", "archive", "--confirm"}
+		cmdArgs = append(cmdArgs, args.Teams...)
+
+		output, err := executeMMCTL(cmdArgs...)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		if output == "" {
+			output = "Teams archived successfully"
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(output)), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register team_archive tool: %v", err)
+	}
+
+	// Register team restore tool
+	err = server.RegisterTool("team_restore", "Restore archived teams", func(args TeamRestoreArgs) (*mcp_golang.ToolResponse, error) {
+		cmdArgs := []string{"team", "restore"}
+		cmdArgs = append(cmdArgs, args.Teams...)
+
+		output, err := executeMMCTL(cmdArgs...)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		if output == "" {
+			output = "Teams restored successfully"
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(output)), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register team_restore tool: %v", err)
+	}
+
+	// Register team delete tool
+	err = server.RegisterTool("team_delete", "Permanently delete a team. Reports the affected channels, posts and members unless confirm is set to the team name", func(args TeamDeleteArgs) (*mcp_golang.ToolResponse, error) {
+		team, err := getTeam(args.Team)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		channels, err := listChannels(team.ID)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		var stats struct {
+			TotalMemberCount int64 `json:"total_member_count"`
+		}
+		if err := executeLocalAPI("GET", "/teams/"+team.ID+"/stats", nil, &stats); err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		impact := TeamDeleteImpact{Team: team.Name, Channels: len(channels), Members: stats.TotalMemberCount}
+		for _, ch := range channels {
+			impact.Posts += ch.TotalMsgCount
+		}
+
+		if args.Confirm != team.Name {
+			impact.Message = fmt.Sprintf("Team not deleted. Call again with confirm set to %q to permanently delete it", team.Name)
+			return newJSONToolResponse(impact), nil
+		}
+
+		if _, err := executeMMCTL("team", "delete", "--confirm", team.ID); err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		impact.Deleted = true
+		impact.Message = "Team permanently deleted"
+		return newJSONToolResponse(impact), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register team_delete tool: %v", err)
+	}
+
+	// Register team clone tool
+	err = server.RegisterTool("team_clone", "Create a new team using an existing team as a template for its channels, settings, group constraints and optionally webhooks", func(args TeamCloneArgs) (*mcp_golang.ToolResponse, error) {
+		result, err := cloneTeam(args)
+		if err != nil {
+			if result == nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+			result.Errors = append(result.Errors, err.Error())
+		}
+		return newJSONToolResponse(result), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register team_clone tool: %v", err)
+	}
+
 	return nil
 }
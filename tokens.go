@@ -0,0 +1,315 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+// SessionListArgs represents arguments for listing a user's sessions
+type SessionListArgs struct {
+	User string `json:"user" jsonschema:"required,description=User to list sessions for (username, email, or ID)"`
+}
+
+// SessionRevokeAllArgs represents arguments for revoking all sessions of users
+type SessionRevokeAllArgs struct {
+	Users []string `json:"users" jsonschema:"required,description=Users whose sessions will be revoked (username, email, or ID)"`
+}
+
+// TokenListArgs represents arguments for token list command
+type TokenListArgs struct {
+	User     string `json:"user" jsonschema:"required,description=User or bot to list tokens for (username, email, or ID)"`
+	Active   bool   `json:"active" jsonschema:"description=Only list active tokens"`
+	Inactive bool   `json:"inactive" jsonschema:"description=Only list inactive tokens"`
+}
+
+// TokenListAllArgs represents arguments for listing the tokens of every user
+type TokenListAllArgs struct {
+	// No specific args for now
+}
+
+// TokenGenerateArgs represents arguments for token generate command
+type TokenGenerateArgs struct {
+	User        string `json:"user" jsonschema:"required,description=User or bot to generate the token for (username, email, or ID)"`
+	Description string `json:"description" jsonschema:"required,description=Description of the token"`
+}
+
+// TokenRevokeArgs represents arguments for token revoke command
+type TokenRevokeArgs struct {
+	TokenIDs []string `json:"tokenIds" jsonschema:"required,description=IDs of the tokens to revoke"`
+}
+
+// Session represents the fields of a Mattermost session used by the tools
+type Session struct {
+	ID             string            `json:"id"`
+	Token          string            `json:"token"`
+	UserID         string            `json:"user_id"`
+	DeviceID       string            `json:"device_id"`
+	CreateAt       int64             `json:"create_at"`
+	ExpiresAt      int64             `json:"expires_at"`
+	LastActivityAt int64             `json:"last_activity_at"`
+	IsOAuth        bool              `json:"is_oauth"`
+	Props          map[string]string `json:"props"`
+}
+
+// UserAccessToken represents a Mattermost personal access token
+type UserAccessToken struct {
+	ID          string `json:"id"`
+	Token       string `json:"token,omitempty"`
+	UserID      string `json:"user_id"`
+	Description string `json:"description"`
+	IsActive    bool   `json:"is_active"`
+}
+
+// SessionInfo summarizes a session without exposing its token
+type SessionInfo struct {
+	ID           string `json:"id"`
+	Token        string `json:"token"`
+	Type         string `json:"type"`
+	Platform     string `json:"platform,omitempty"`
+	OS           string `json:"os,omitempty"`
+	Browser      string `json:"browser,omitempty"`
+	DeviceID     string `json:"deviceId,omitempty"`
+	CreatedAt    string `json:"createdAt"`
+	LastActivity string `json:"lastActivity"`
+	ExpiresAt    string `json:"expiresAt,omitempty"`
+}
+
+// TokenInfo summarizes a personal access token without exposing its value
+type TokenInfo struct {
+	ID          string `json:"id"`
+	Token       string `json:"token,omitempty"`
+	Owner       string `json:"owner"`
+	OwnerID     string `json:"ownerId"`
+	Description string `json:"description"`
+	Active      bool   `json:"active"`
+	FirstUsedAt string `json:"firstUsedAt,omitempty"`
+}
+
+// redactSecret hides all but the first characters of a token or session secret
+func redactSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	if len(secret) <= 4 {
+		return strings.Repeat("*", len(secret))
+	}
+	return secret[:4] + strings.Repeat("*", len(secret)-4)
+}
+
+// newSessionInfo builds the redacted summary of a session
+func newSessionInfo(s Session) SessionInfo {
+	sessionType := "web"
+	switch {
+	case s.Props["type"] == "UserAccessToken":
+		sessionType = "token"
+	case s.IsOAuth:
+		sessionType = "oauth"
+	case s.DeviceID != "":
+		sessionType = "mobile"
+	}
+
+	info := SessionInfo{
+		ID:           s.ID,
+		Token:        redactSecret(s.Token),
+		Type:         sessionType,
+		Platform:     s.Props["platform"],
+		OS:           s.Props["os"],
+		Browser:      s.Props["browser"],
+		DeviceID:     s.DeviceID,
+		CreatedAt:    formatMillis(s.CreateAt),
+		LastActivity: formatMillis(s.LastActivityAt),
+	}
+	if s.ExpiresAt > 0 {
+		info.ExpiresAt = formatMillis(s.ExpiresAt)
+	}
+	return info
+}
+
+// newTokenInfo builds the redacted summary of a personal access token
+func newTokenInfo(t UserAccessToken, owner string, firstUsedAt int64) TokenInfo {
+	return TokenInfo{
+		ID:          t.ID,
+		Token:       redactSecret(t.Token),
+		Owner:       owner,
+		OwnerID:     t.UserID,
+		Description: t.Description,
+		Active:      t.IsActive,
+		FirstUsedAt: formatMillis(firstUsedAt),
+	}
+}
+
+// tokenFirstUseTimes maps token IDs to the creation time of their oldest
+// current session, the earliest use still on record. The token model itself
+// has no creation timestamp, and unused tokens have no sessions.
+func tokenFirstUseTimes(userID string) map[string]int64 {
+	times := map[string]int64{}
+
+	var sessions []Session
+	if err := executeLocalAPI("GET", "/users/"+userID+"/sessions", nil, &sessions); err != nil {
+		return times
+	}
+
+	for _, s := range sessions {
+		id := s.Props["user_access_token_id"]
+		if id == "" {
+			continue
+		}
+		if t, ok := times[id]; !ok || s.CreateAt < t {
+			times[id] = s.CreateAt
+		}
+	}
+	return times
+}
+
+// RegisterTokenTools registers all session and access token related tools
+func RegisterTokenTools(server *mcp_golang.Server) error {
+	// Register session list tool
+	err := server.RegisterTool("session_list", "List the active sessions of a user", func(args SessionListArgs) (*mcp_golang.ToolResponse, error) {
+		user, err := getUser(args.User)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		var sessions []Session
+		if err := executeLocalAPI("GET", "/users/"+user.ID+"/sessions", nil, &sessions); err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		infos := []SessionInfo{}
+		for _, s := range sessions {
+			infos = append(infos, newSessionInfo(s))
+		}
+		return newJSONToolResponse(infos), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register session_list tool: %v", err)
+	}
+
+	// Register session revoke all tool
+	err = server.RegisterTool("session_revoke_all", "Revoke all sessions of users, logging them out everywhere", func(args SessionRevokeAllArgs) (*mcp_golang.ToolResponse, error) {
+		var lines []string
+		for _, term := range args.Users {
+			user, err := getUser(term)
+			if err != nil {
+				lines = append(lines, fmt.Sprintf("Error: %v", err))
+				continue
+			}
+
+			if err := executeLocalAPI("POST", "/users/"+user.ID+"/sessions/revoke/all", nil, nil); err != nil {
+				lines = append(lines, fmt.Sprintf("Error revoking sessions for %s: %v", user.Username, err))
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("Revoked all sessions for %s", user.Username))
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(strings.Join(lines, "\n"))), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register session_revoke_all tool: %v", err)
+	}
+
+	// Register token list tool
+	err = server.RegisterTool("token_list", "List the personal access tokens of a user or bot", func(args TokenListArgs) (*mcp_golang.ToolResponse, error) {
+		user, err := getUser(args.User)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		cmdArgs := []string{"token", "list", user.ID, "--all"}
+
+		if args.Active {
+			cmdArgs = append(cmdArgs, "--active")
+		}
+
+		if args.Inactive {
+			cmdArgs = append(cmdArgs, "--inactive")
+		}
+
+		var tokens []UserAccessToken
+		if err := executeMMCTLJSON(&tokens, cmdArgs...); err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		firstUsed := tokenFirstUseTimes(user.ID)
+		infos := []TokenInfo{}
+		for _, t := range tokens {
+			infos = append(infos, newTokenInfo(t, user.Username, firstUsed[t.ID]))
+		}
+		return newJSONToolResponse(infos), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register token_list tool: %v", err)
+	}
+
+	// Register token list all tool
+	err = server.RegisterTool("token_list_all", "List the personal access tokens of every user on the server", func(args TokenListAllArgs) (*mcp_golang.ToolResponse, error) {
+		var tokens []UserAccessToken
+		for page := 0; ; page++ {
+			var batch []UserAccessToken
+			if err := executeLocalAPI("GET", fmt.Sprintf("/users/tokens?page=%d&per_page=200", page), nil, &batch); err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+			tokens = append(tokens, batch...)
+			if len(batch) < 200 {
+				break
+			}
+		}
+
+		owners := map[string]string{}
+		firstUsed := map[string]map[string]int64{}
+		infos := []TokenInfo{}
+		for _, t := range tokens {
+			if _, ok := owners[t.UserID]; !ok {
+				owners[t.UserID] = t.UserID
+				if user, err := getUser(t.UserID); err == nil {
+					owners[t.UserID] = user.Username
+				}
+				firstUsed[t.UserID] = tokenFirstUseTimes(t.UserID)
+			}
+			infos = append(infos, newTokenInfo(t, owners[t.UserID], firstUsed[t.UserID][t.ID]))
+		}
+		return newJSONToolResponse(infos), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register token_list_all tool: %v", err)
+	}
+
+	// Register token generate tool
+	err = server.RegisterTool("token_generate", "Generate a personal access token for a user or bot", func(args TokenGenerateArgs) (*mcp_golang.ToolResponse, error) {
+		// The token value is only shown here, so it is intentionally not redacted
+		cmdArgs := []string{"token", "generate", args.User, args.Description}
+
+		output, err := executeMMCTL(cmdArgs...)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		if output == "" {
+			output = "Token generated successfully"
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(output)), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register token_generate tool: %v", err)
+	}
+
+	// Register token revoke tool
+	err = server.RegisterTool("token_revoke", "Revoke personal access tokens", func(args TokenRevokeArgs) (*mcp_golang.ToolResponse, error) {
+		cmdArgs := []string{"token", "revoke"}
+		cmdArgs = append(cmdArgs, args.TokenIDs...)
+
+		output, err := executeMMCTL(cmdArgs...)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		if output == "" {
+			output = "Tokens revoked successfully"
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(output)), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register token_revoke tool: %v", err)
+	}
+
+	return nil
+}
@@ -2,10 +2,92 @@ package main
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	mcp_golang "github.com/metoro-io/mcp-golang"
 )
 
+// User represents the fields of a Mattermost user used by the tools
+type User struct {
+	ID             string `json:"id"`
+	Username       string `json:"username"`
+	Email          string `json:"email"`
+	FirstName      string `json:"first_name"`
+	LastName       string `json:"last_name"`
+	Nickname       string `json:"nickname"`
+	Roles          string `json:"roles"`
+	AuthService    string `json:"auth_service"`
+	IsBot          bool   `json:"is_bot"`
+	CreateAt       int64  `json:"create_at"`
+	DeleteAt       int64  `json:"delete_at"`
+	LastActivityAt int64  `json:"last_activity_at"`
+	LastLogin      int64  `json:"last_login"`
+}
+
+// IsGuest reports whether the user has the system guest role
+func (u User) IsGuest() bool {
+	return strings.Contains(" "+u.Roles+" ", " system_guest ")
+}
+
+// LastActivity returns the most recent login timestamp known for the user,
+// falling back to the creation time for users that never logged in. The
+// server leaves last_activity_at out of user lists, so session use and posts
+// need to be checked separately
+func (u User) LastActivity() int64 {
+	last := u.CreateAt
+	if u.LastActivityAt > last {
+		last = u.LastActivityAt
+	}
+	if u.LastLogin > last {
+		last = u.LastLogin
+	}
+	return last
+}
+
+// listUsers fetches all users, optionally restricted to the members of a team
+func listUsers(team string) ([]User, error) {
+	cmdArgs := []string{"user", "list", "--all"}
+	if team != "" {
+		cmdArgs = append(cmdArgs, "--team", team)
+	}
+
+	var users []User
+	if err := executeMMCTLJSON(&users, cmdArgs...); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// getUsersByIDs fetches the users with the given IDs, keyed by ID
+func getUsersByIDs(ids []string) (map[string]User, error) {
+	users := map[string]User{}
+	if len(ids) == 0 {
+		return users, nil
+	}
+
+	var list []User
+	if err := executeLocalAPI("POST", "/users/ids", ids, &list); err != nil {
+		return nil, err
+	}
+	for _, u := range list {
+		users[u.ID] = u
+	}
+	return users, nil
+}
+
+// getUser looks up a single user by username, email or ID
+func getUser(term string) (*User, error) {
+	var users []User
+	if err := executeMMCTLJSON(&users, "user", "search", term); err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("user %s not found", term)
+	}
+	return &users[0], nil
+}
+
 // UserSearchArgs represents arguments for user search command
 type UserSearchArgs struct {
 	Terms []string `json:"terms" jsonschema:"required,description=Terms to search for (email, username, or user ID)"`
@@ -54,6 +136,134 @@ type UserAddChannelArgs struct {
 	Users   []string `json:"users" jsonschema:"required,description=Users to add (usernames, emails, or IDs)"`
 }
 
+// UserInactiveReportArgs represents arguments for the inactive user report
+type UserInactiveReportArgs struct {
+	Days        int    `json:"days" jsonschema:"required,description=Report users with no login, session use or posts in this many days"`
+	Team        string `json:"team" jsonschema:"description=Only consider members of this team (name or ID)"`
+	AuthService string `json:"authService" jsonschema:"description=Only consider users of this auth service (email, ldap, saml, gitlab, ...)"`
+	IncludeBots bool   `json:"includeBots" jsonschema:"description=Include bot accounts in the report"`
+	Deactivate  bool   `json:"deactivate" jsonschema:"description=Deactivate the reported users; run without it first to preview"`
+	BatchSize   int    `json:"batchSize" jsonschema:"description=Number of users deactivated per mmctl call (default 50)"`
+}
+
+// InactiveUser summarizes an inactive user in the report
+type InactiveUser struct {
+	ID           string `json:"id"`
+	Username     string `json:"username"`
+	Email        string `json:"email"`
+	LastActivity string `json:"lastActivity"`
+	InactiveDays int    `json:"inactiveDays"`
+}
+
+// InactiveUserReport groups inactive users by team and auth service
+type InactiveUserReport struct {
+	Cutoff      string                               `json:"cutoff"`
+	Total       int                                  `json:"total"`
+	Groups      map[string]map[string][]InactiveUser `json:"groups"`
+	Deactivated int                                  `json:"deactivated"`
+	Errors      []string                             `json:"errors,omitempty"`
+}
+
+// userActivityChecker finds activity that the login time of a user misses:
+// use of their sessions, such as personal access tokens or mobile apps, and posts
+type userActivityChecker struct {
+	since     int64
+	lastPosts map[string]map[string]int64
+}
+
+// newUserActivityChecker creates a checker for activity after since (milliseconds)
+func newUserActivityChecker(since int64) *userActivityChecker {
+	return &userActivityChecker{since: since, lastPosts: map[string]map[string]int64{}}
+}
+
+// lastActivity returns the most recent session use or post of a user, only
+// looking at posts after since
+func (c *userActivityChecker) lastActivity(userID string) (int64, error) {
+	var last int64
+
+	var sessions []Session
+	if err := executeLocalAPI("GET", "/users/"+userID+"/sessions", nil, &sessions); err != nil {
+		return 0, err
+	}
+	for _, s := range sessions {
+		if s.LastActivityAt > last {
+			last = s.LastActivityAt
+		}
+	}
+	if last >= c.since {
+		return last, nil
+	}
+
+	var channels []Channel
+	if err := executeLocalAPI("GET", "/users/"+userID+"/channels", nil, &channels); err != nil {
+		return 0, err
+	}
+	for _, ch := range channels {
+		// Channel history is fetched once and shared by all users checked
+		lastPosts, ok := c.lastPosts[ch.ID]
+		if !ok {
+			posts, err := fetchChannelPosts(ch.ID, c.since, 0)
+			if err != nil {
+				return 0, err
+			}
+			lastPosts = map[string]int64{}
+			for _, p := range posts {
+				if p.Type == "" && p.CreateAt > lastPosts[p.UserID] {
+					lastPosts[p.UserID] = p.CreateAt
+				}
+			}
+			c.lastPosts[ch.ID] = lastPosts
+		}
+		if lastPosts[userID] > last {
+			last = lastPosts[userID]
+		}
+	}
+	return last, nil
+}
+
+// lastActive returns the most recent login, session use or post of a user,
+// only checking sessions and posts when the login is older than since
+func (c *userActivityChecker) lastActive(u User) (int64, error) {
+	last := u.LastActivity()
+	if last >= c.since {
+		return last, nil
+	}
+
+	// Users may post through tokens or mobile sessions without logging in again
+	recent, err := c.lastActivity(u.ID)
+	if err != nil {
+		return 0, err
+	}
+	if recent > last {
+		last = recent
+	}
+	return last, nil
+}
+
+// userTeamNames maps user IDs to the names of the teams they belong to
+func userTeamNames(team string) (map[string][]string, error) {
+	teams, err := listTeams()
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string][]string{}
+	for _, t := range teams {
+		if t.DeleteAt != 0 || (team != "" && team != t.Name && team != t.ID) {
+			continue
+		}
+
+		members, err := listUsers(t.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range members {
+			names[m.ID] = append(names[m.ID], t.Name)
+		}
+	}
+	return names, nil
+}
+
 // RegisterUserTools registers all user related tools
 func RegisterUserTools(server *mcp_golang.Server) error {
 	// Register user search tool
@@ -216,5 +426,102 @@ func RegisterUserTools(server *mcp_golang.Server) error {
 		return fmt.Errorf("failed to register user_add_channel tool: %v", err)
 	}
 
+	// Register inactive user report tool
+	err = server.RegisterTool("user_inactive_report", "Report users with no login, session use or posts in N days, grouped by team and auth service, and optionally deactivate them in batches", func(args UserInactiveReportArgs) (*mcp_golang.ToolResponse, error) {
+		if args.Days <= 0 {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: days must be greater than zero")), nil
+		}
+
+		batchSize := args.BatchSize
+		if batchSize <= 0 {
+			batchSize = 50
+		}
+
+		users, err := listUsers(args.Team)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		teamNames, err := userTeamNames(args.Team)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		now := time.Now()
+		cutoff := now.AddDate(0, 0, -args.Days)
+		report := InactiveUserReport{
+			Cutoff: cutoff.UTC().Format(time.RFC3339),
+			Groups: map[string]map[string][]InactiveUser{},
+		}
+
+		activity := newUserActivityChecker(cutoff.UnixMilli())
+		var inactive []string
+		for _, u := range users {
+			if u.DeleteAt != 0 || (u.IsBot && !args.IncludeBots) {
+				continue
+			}
+
+			authService := u.AuthService
+			if authService == "" {
+				authService = "email"
+			}
+			if args.AuthService != "" && args.AuthService != authService {
+				continue
+			}
+
+			last, err := activity.lastActive(u)
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("%s: activity not checked, user skipped: %v", u.Username, err))
+				continue
+			}
+			if last >= cutoff.UnixMilli() {
+				continue
+			}
+
+			entry := InactiveUser{
+				ID:           u.ID,
+				Username:     u.Username,
+				Email:        u.Email,
+				LastActivity: formatMillis(last),
+				InactiveDays: int(now.Sub(time.UnixMilli(last)).Hours() / 24),
+			}
+
+			teams := teamNames[u.ID]
+			if len(teams) == 0 {
+				teams = []string{"(no team)"}
+			}
+			for _, team := range teams {
+				if report.Groups[team] == nil {
+					report.Groups[team] = map[string][]InactiveUser{}
+				}
+				report.Groups[team][authService] = append(report.Groups[team][authService], entry)
+			}
+
+			inactive = append(inactive, u.ID)
+		}
+		report.Total = len(inactive)
+
+		if args.Deactivate {
+			for start := 0; start < len(inactive); start += batchSize {
+				end := start + batchSize
+				if end > len(inactive) {
+					end = len(inactive)
+				}
+
+				cmdArgs := append([]string{"user", "deactivate"}, inactive[start:end]...)
+				if _, err := executeMMCTL(cmdArgs...); err != nil {
+					report.Errors = append(report.Errors, fmt.Sprintf("batch %d: %v", start/batchSize+1, err))
+					continue
+				}
+				report.Deactivated += end - start
+			}
+		}
+
+		return newJSONToolResponse(report), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register user_inactive_report tool: %v", err)
+	}
+
 	return nil
 }
@@ -1,11 +1,30 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
 
 	mcp_golang "github.com/metoro-io/mcp-golang"
 )
 
+// IncomingWebhook represents the fields of a Mattermost incoming webhook used by the tools
+type IncomingWebhook struct {
+	ID            string `json:"id"`
+	ChannelID     string `json:"channel_id"`
+	TeamID        string `json:"team_id"`
+	UserID        string `json:"user_id"`
+	DisplayName   string `json:"display_name"`
+	Description   string `json:"description"`
+	Username      string `json:"username"`
+	IconURL       string `json:"icon_url"`
+	ChannelLocked bool   `json:"channel_locked"`
+}
+
 // WebhookListArgs represents arguments for webhook list command
 type WebhookListArgs struct {
 	Team string `json:"team" jsonschema:"description=Team name or ID to filter webhooks by"`
@@ -45,6 +64,50 @@ type WebhookDeleteArgs struct {
 	WebhookID string `json:"webhookId" jsonschema:"required,description=ID of the webhook to delete"`
 }
 
+// WebhookTestIncomingArgs represents arguments for test-firing an incoming webhook
+type WebhookTestIncomingArgs struct {
+	WebhookID   string                   `json:"webhookId" jsonschema:"required,description=ID of the incoming webhook"`
+	Text        string                   `json:"text" jsonschema:"description=Message text (default a test message)"`
+	Attachments []map[string]interface{} `json:"attachments" jsonschema:"description=Message attachments in Mattermost format"`
+	Username    string                   `json:"username" jsonschema:"description=Override the posting username"`
+	IconURL     string                   `json:"iconUrl" jsonschema:"description=Override the posting icon URL"`
+	Channel     string                   `json:"channel" jsonschema:"description=Override the channel (channel name, or team:channel); not allowed for hooks locked to their channel"`
+	BaseURL     string                   `json:"baseUrl" jsonschema:"description=Server URL to send the payload to (default ServiceSettings.SiteURL)"`
+}
+
+// WebhookTestResult reports the outcome of a webhook test
+type WebhookTestResult struct {
+	URL        string `json:"url"`
+	StatusCode int    `json:"statusCode"`
+	Response   string `json:"response"`
+	Channel    string `json:"channel"`
+	Delivered  bool   `json:"delivered"`
+	PostID     string `json:"postId,omitempty"`
+	Message    string `json:"message"`
+}
+
+// findWebhookPost waits for a post created by an incoming webhook in a channel
+// since the given time (milliseconds)
+func findWebhookPost(channelID string, since int64, text string) (*Post, error) {
+	for attempt := 0; attempt < 10; attempt++ {
+		posts, err := fetchChannelPosts(channelID, since, 0)
+		if err != nil {
+			return nil, err
+		}
+		for i := range posts {
+			p := &posts[i]
+			if p.Props["from_webhook"] != "true" {
+				continue
+			}
+			if text == "" || p.Message == text {
+				return p, nil
+			}
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	return nil, nil
+}
+
 // RegisterWebhookTools registers all webhook related tools
 func RegisterWebhookTools(server *mcp_golang.Server) error {
 	// Register webhook list tool
@@ -180,5 +243,106 @@ func RegisterWebhookTools(server *mcp_golang.Server) error {
 		return fmt.Errorf("failed to register webhook_delete tool: %v", err)
 	}
 
+	// Register webhook test incoming tool
+	err = server.RegisterTool("webhook_test_incoming", "Send a test payload to an incoming webhook and confirm the post landed in the expected channel", func(args WebhookTestIncomingArgs) (*mcp_golang.ToolResponse, error) {
+		var hook IncomingWebhook
+		if err := executeLocalAPI("GET", "/hooks/incoming/"+args.WebhookID, nil, &hook); err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		channel, err := getChannel(hook.ChannelID)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		payload := map[string]interface{}{}
+		if args.Channel != "" {
+			ref := args.Channel
+			if !strings.Contains(ref, ":") {
+				team, err := getTeam(hook.TeamID)
+				if err != nil {
+					return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+				}
+				ref = team.Name + ":" + ref
+			}
+			if channel, err = getChannel(ref); err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+			}
+			if channel.TeamID != hook.TeamID {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: webhooks can only post to channels of their own team")), nil
+			}
+			if hook.ChannelLocked && channel.ID != hook.ChannelID {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent("Error: webhook is locked to its channel and cannot post elsewhere")), nil
+			}
+			payload["channel"] = channel.Name
+		}
+
+		text := args.Text
+		if text == "" && len(args.Attachments) == 0 {
+			text = fmt.Sprintf("Test message for incoming webhook %s", hook.ID)
+		}
+		if text != "" {
+			payload["text"] = text
+		}
+		if len(args.Attachments) > 0 {
+			payload["attachments"] = args.Attachments
+		}
+		if args.Username != "" {
+			payload["username"] = args.Username
+		}
+		if args.IconURL != "" {
+			payload["icon_url"] = args.IconURL
+		}
+
+		baseURL := args.BaseURL
+		if baseURL == "" {
+			if baseURL, err = siteURL(); err != nil {
+				return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v; pass baseUrl instead", err))), nil
+			}
+		}
+
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+
+		result := WebhookTestResult{URL: strings.TrimRight(baseURL, "/") + "/hooks/" + hook.ID, Channel: channel.Name}
+
+		// Allow for small clock differences between this host and the server
+		since := time.Now().Add(-5 * time.Second).UnixMilli()
+
+		client := &http.Client{Timeout: 30 * time.Second}
+		resp, err := client.Post(result.URL, "application/json", bytes.NewReader(data))
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		result.StatusCode = resp.StatusCode
+		result.Response = strings.TrimSpace(string(body))
+		if resp.StatusCode >= 300 {
+			result.Message = "Webhook request failed"
+			return newJSONToolResponse(result), nil
+		}
+
+		post, err := findWebhookPost(channel.ID, since, text)
+		if err != nil {
+			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(fmt.Sprintf("Error: %v", err))), nil
+		}
+		if post == nil {
+			result.Message = fmt.Sprintf("Webhook accepted the payload, but no post appeared in %s", channel.Name)
+			return newJSONToolResponse(result), nil
+		}
+
+		result.Delivered = true
+		result.PostID = post.ID
+		result.Message = fmt.Sprintf("Post delivered to %s", channel.Name)
+		return newJSONToolResponse(result), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register webhook_test_incoming tool: %v", err)
+	}
+
 	return nil
 }